@@ -0,0 +1,102 @@
+package gomcsmp
+
+import (
+	"context"
+	"fmt"
+)
+
+// AllowlistPlan - the changes needed to turn the live allowlist into the desired one.
+// Players are matched by UUID when both sides have one, otherwise by name ignoring case,
+// so a known name with a different UUID is both removed and added.
+type AllowlistPlan struct {
+	Add       []Player
+	Remove    []Player
+	Unchanged int
+
+	// Applied - whether the plan was carried out in full, false for dry runs and failures.
+	Applied bool
+	// Result - the allowlist read back after applying, nil when nothing was sent or it could not be read.
+	Result *PlayerRegistry
+}
+
+// Empty - reports whether the live allowlist already matches.
+func (p *AllowlistPlan) Empty() bool {
+	return len(p.Add) == 0 && len(p.Remove) == 0
+}
+
+type reconcileConfig struct {
+	dryRun bool
+}
+
+// ReconcileOption - configures ReconcileAllowlist.
+type ReconcileOption func(cfg *reconcileConfig)
+
+// ReconcileDryRun - computes the plan without changing the allowlist.
+func ReconcileDryRun() ReconcileOption {
+	return func(cfg *reconcileConfig) {
+		cfg.dryRun = true
+	}
+}
+
+// ReconcileAllowlist - brings the allowlist to the desired players with the fewest changes.
+// Unlike AllowlistSet, players on both lists stay allowlisted throughout.
+// Additions are sent before removals, so a failed apply never leaves out a desired
+// player: it stops with the live allowlist unchanged or holding both old and new players.
+// The plan is returned even when applying it fails.
+func ReconcileAllowlist(ctx context.Context, api AllowlistAPI, desired []Player, opts ...ReconcileOption) (*AllowlistPlan, error) {
+	var cfg reconcileConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	current, err := api.AllowlistGet(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowlist: %w", err)
+	}
+
+	want := NewPlayerRegistry(desired)
+	plan := &AllowlistPlan{
+		Add:    want.Difference(current).Players(),
+		Remove: current.Difference(want).Players(),
+	}
+	plan.Unchanged = current.Len() - len(plan.Remove)
+
+	// only new entries are checked, live ones may predate the current name rules
+	if err := validatePlayers(plan.Add...); err != nil {
+		return nil, err
+	}
+
+	if cfg.dryRun {
+		return plan, nil
+	}
+	if plan.Empty() {
+		plan.Applied = true
+		return plan, nil
+	}
+
+	if err := applyAllowlistPlan(ctx, api, plan); err != nil {
+		plan.Result, _ = api.AllowlistGet(ctx)
+		return plan, err
+	}
+
+	plan.Applied = true
+	plan.Result, err = api.AllowlistGet(ctx)
+	if err != nil {
+		return plan, fmt.Errorf("failed to get updated allowlist: %w", err)
+	}
+	return plan, nil
+}
+
+func applyAllowlistPlan(ctx context.Context, api AllowlistAPI, plan *AllowlistPlan) error {
+	if len(plan.Add) > 0 {
+		if err := api.AllowlistAdd(ctx, plan.Add...); err != nil {
+			return fmt.Errorf("failed to add players to allowlist: %w", err)
+		}
+	}
+	if len(plan.Remove) > 0 {
+		if err := api.AllowlistRemove(ctx, plan.Remove...); err != nil {
+			return fmt.Errorf("failed to remove players from allowlist: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package gomcsmp_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+	"github.com/eterline/go-mc-smp/mcsmptest"
+	"github.com/google/uuid"
+)
+
+func playerNames(players []gomcsmp.Player) []string {
+	names := make([]string, len(players))
+	for i, p := range players {
+		names[i] = p.Name
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestAllowlistReconcile(t *testing.T) {
+	steveID, alexID := uuid.New(), uuid.New()
+
+	steve := gomcsmp.Player{Name: "Steve", ID: &steveID}
+	alex := gomcsmp.Player{Name: "Alex", ID: &alexID}
+	otherAlexID := uuid.New()
+
+	tests := []struct {
+		name       string
+		current    []gomcsmp.Player
+		desired    []gomcsmp.Player
+		wantAdd    []string
+		wantRemove []string
+		unchanged  int
+		calls      []string
+	}{
+		{
+			name:      "already matching",
+			current:   []gomcsmp.Player{steve, alex},
+			desired:   []gomcsmp.Player{alex, steve},
+			unchanged: 2,
+		},
+		{
+			name:      "add only",
+			current:   []gomcsmp.Player{steve},
+			desired:   []gomcsmp.Player{steve, gomcsmp.NewPlayer("Notch")},
+			wantAdd:   []string{"Notch"},
+			unchanged: 1,
+			calls:     []string{"minecraft:allowlist/add"},
+		},
+		{
+			name:       "add and remove",
+			current:    []gomcsmp.Player{steve, alex},
+			desired:    []gomcsmp.Player{steve, gomcsmp.NewPlayer("Notch")},
+			wantAdd:    []string{"Notch"},
+			wantRemove: []string{"Alex"},
+			unchanged:  1,
+			calls:      []string{"minecraft:allowlist/add", "minecraft:allowlist/remove"},
+		},
+		{
+			name:      "name matched ignoring case",
+			current:   []gomcsmp.Player{gomcsmp.NewPlayer("Steve")},
+			desired:   []gomcsmp.Player{gomcsmp.NewPlayer("steve")},
+			unchanged: 1,
+		},
+		{
+			name:       "same name other uuid",
+			current:    []gomcsmp.Player{alex},
+			desired:    []gomcsmp.Player{{Name: "Alex", ID: &otherAlexID}},
+			wantAdd:    []string{"Alex"},
+			wantRemove: []string{"Alex"},
+			calls:      []string{"minecraft:allowlist/add", "minecraft:allowlist/remove"},
+		},
+		{
+			// live entries are removed even when they break the current name rules
+			name:       "remove legacy name",
+			current:    []gomcsmp.Player{steve, gomcsmp.NewPlayer("old name!")},
+			desired:    []gomcsmp.Player{steve},
+			wantRemove: []string{"old name!"},
+			unchanged:  1,
+			calls:      []string{"minecraft:allowlist/remove"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := mcsmptest.Start(t)
+			client := srv.TestClient(t)
+			srv.Update(func(st *mcsmptest.State) { st.Allowlist = slices.Clone(tt.current) })
+
+			plan, err := gomcsmp.ReconcileAllowlist(context.Background(), client, tt.desired)
+			if err != nil {
+				t.Fatalf("ReconcileAllowlist: %v", err)
+			}
+
+			if got := playerNames(plan.Add); !slices.Equal(got, tt.wantAdd) {
+				t.Fatalf("add = %v, want %v", got, tt.wantAdd)
+			}
+			if got := playerNames(plan.Remove); !slices.Equal(got, tt.wantRemove) {
+				t.Fatalf("remove = %v, want %v", got, tt.wantRemove)
+			}
+			if plan.Unchanged != tt.unchanged {
+				t.Fatalf("unchanged = %d, want %d", plan.Unchanged, tt.unchanged)
+			}
+			if !plan.Applied {
+				t.Fatal("plan not applied")
+			}
+
+			var mutations []string
+			for _, m := range srv.Calls() {
+				if m != "minecraft:allowlist" {
+					mutations = append(mutations, m)
+				}
+			}
+			if !slices.Equal(mutations, tt.calls) {
+				t.Fatalf("calls = %v, want %v", mutations, tt.calls)
+			}
+
+			live := gomcsmp.NewPlayerRegistry(srv.State().Allowlist)
+			want := gomcsmp.NewPlayerRegistry(tt.desired)
+			if live.Difference(want).Len() != 0 || want.Difference(live).Len() != 0 {
+				t.Fatalf("allowlist = %v, want %v", playerNames(live.Players()), playerNames(tt.desired))
+			}
+		})
+	}
+}
+
+func TestAllowlistReconcileDryRun(t *testing.T) {
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+	srv.Update(func(st *mcsmptest.State) { st.Allowlist = []gomcsmp.Player{gomcsmp.NewPlayer("Steve")} })
+
+	plan, err := gomcsmp.ReconcileAllowlist(context.Background(), client, []gomcsmp.Player{gomcsmp.NewPlayer("Alex")}, gomcsmp.ReconcileDryRun())
+	if err != nil {
+		t.Fatalf("ReconcileAllowlist: %v", err)
+	}
+	if plan.Applied || plan.Result != nil {
+		t.Fatalf("dry run plan applied = %v, result = %v", plan.Applied, plan.Result)
+	}
+	if len(plan.Add) != 1 || len(plan.Remove) != 1 {
+		t.Fatalf("plan add = %v, remove = %v, want one each", plan.Add, plan.Remove)
+	}
+	if got := srv.State().Allowlist; len(got) != 1 || got[0].Name != "Steve" {
+		t.Fatalf("allowlist changed by a dry run: %+v", got)
+	}
+}
+
+func TestAllowlistReconcileInvalidAdd(t *testing.T) {
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+
+	_, err := gomcsmp.ReconcileAllowlist(context.Background(), client, []gomcsmp.Player{gomcsmp.NewPlayer("bad name!")})
+	if !errors.Is(err, gomcsmp.ErrInvalidPlayerName) {
+		t.Fatalf("error = %v, want ErrInvalidPlayerName", err)
+	}
+	if n := srv.CallCount("minecraft:allowlist/add"); n != 0 {
+		t.Fatalf("allowlist/add called %d times, want 0", n)
+	}
+}
+
+func TestAllowlistReconcilePartialFailure(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault mcsmptest.Fault
+		want  []string
+	}{
+		// nothing was removed before the failed addition
+		{"add fails", mcsmptest.FailWith("minecraft:allowlist/add", "boom"), []string{"Steve"}},
+		// the desired player is already in, the stale one is left over
+		{"remove fails", mcsmptest.FailWith("minecraft:allowlist/remove", "boom"), []string{"Steve", "Alex"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := mcsmptest.Start(t, mcsmptest.WithFaultPlan(mcsmptest.NewFaultPlan(tt.fault)))
+			client := srv.TestClient(t)
+			srv.Update(func(st *mcsmptest.State) { st.Allowlist = []gomcsmp.Player{gomcsmp.NewPlayer("Steve")} })
+
+			result, err := gomcsmp.ReconcileAllowlist(context.Background(), client, []gomcsmp.Player{gomcsmp.NewPlayer("Alex")})
+			if err == nil {
+				t.Fatal("ReconcileAllowlist succeeded, want the injected error")
+			}
+			if result == nil || result.Applied {
+				t.Fatalf("plan = %+v, want an unapplied plan", result)
+			}
+			if result.Result == nil || result.Result.Len() != len(tt.want) {
+				t.Fatalf("result = %v, want %v", result.Result, tt.want)
+			}
+			for _, name := range tt.want {
+				if !result.Result.Contains(name) {
+					t.Fatalf("result = %v, want %v", result.Result.Players(), tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package gomcsmp
+
+import (
+	"context"
+	"time"
+)
+
+// Domain interfaces implemented by RPCClient.
+// They hold protocol methods only, helpers such as KickPlayers or ApplySettings
+// are package functions over them. Services may depend on the narrowest interface
+// they need, so fakes and decorators can be substituted in tests.
+
+// PlayersAPI - methods of minecraft:players
+type PlayersAPI interface {
+	PlayersGet(ctx context.Context) (*PlayerRegistry, error)
+	PlayersKick(ctx context.Context, kicks ...KickPlayer) (*PlayerRegistry, error)
+}
+
+// AllowlistAPI - methods of minecraft:allowlist
+type AllowlistAPI interface {
+	AllowlistGet(ctx context.Context) (*PlayerRegistry, error)
+	AllowlistAdd(ctx context.Context, p ...Player) error
+	AllowlistRemove(ctx context.Context, p ...Player) error
+	AllowlistClear(ctx context.Context) error
+	AllowlistSet(ctx context.Context, p ...Player) error
+}
+
+// BansAPI - methods of minecraft:bans
+type BansAPI interface {
+	BansGet(ctx context.Context) ([]UserBan, error)
+	BansSet(ctx context.Context, ban ...UserBan) error
+	BansAdd(ctx context.Context, ban ...UserBan) error
+	BansRemove(ctx context.Context, player ...Player) error
+	BansClear(ctx context.Context) error
+}
+
+// IPBansAPI - methods of minecraft:ip_bans
+type IPBansAPI interface {
+	IPBansGet(ctx context.Context) ([]IPBan, error)
+	IPBansSet(ctx context.Context, ban ...IPBan) error
+	IPBansAdd(ctx context.Context, ban ...IPBan) error
+	IPBansRemove(ctx context.Context, player ...IPBan) error
+	IPBansClear(ctx context.Context) error
+}
+
+// OperatorsAPI - methods of minecraft:operators
+type OperatorsAPI interface {
+	OperatorsGet(ctx context.Context) ([]Operator, error)
+	OperatorsSet(ctx context.Context, p ...Operator) error
+	OperatorsAdd(ctx context.Context, p ...Operator) error
+	OperatorsRemove(ctx context.Context, p ...Player) error
+	OperatorsClear(ctx context.Context) error
+}
+
+// GamerulesAPI - methods of minecraft:gamerules
+type GamerulesAPI interface {
+	GamerulesGet(ctx context.Context) (GameRules, error)
+	GamerulesUpdate(ctx context.Context, rule GameRule) (*GameRule, error)
+}
+
+// ServerAPI - methods of minecraft:server
+type ServerAPI interface {
+	ServerStatus(ctx context.Context) (*ServerState, error)
+	ServerSave(ctx context.Context, flush bool) (bool, error)
+	ServerStop(ctx context.Context) (bool, error)
+	ServerSystemMessage(ctx context.Context, message SystemMessage) (bool, error)
+}
+
+// SettingsAPI - methods of minecraft:serversettings
+type SettingsAPI interface {
+	SettingsAutosave(ctx context.Context) (bool, error)
+	SettingsAutosaveSet(ctx context.Context, enable bool) (bool, error)
+	SettingsDifficulty(ctx context.Context) (string, error)
+	SettingsDifficultySet(ctx context.Context, difficulty string) (string, error)
+	SettingsDifficultyLevel(ctx context.Context) (Difficulty, error)
+	SettingsDifficultyLevelSet(ctx context.Context, difficulty Difficulty) (Difficulty, error)
+	SettingsEnforceAllowlist(ctx context.Context) (bool, error)
+	SettingsEnforceAllowlistSet(ctx context.Context, enforce bool) (bool, error)
+	SettingsUseAllowlist(ctx context.Context) (bool, error)
+	SettingsUseAllowlistSet(ctx context.Context, use bool) (bool, error)
+	SettingsMaxPlayers(ctx context.Context) (int, error)
+	SettingsMaxPlayersSet(ctx context.Context, max int) (int, error)
+	SettingsPauseWhenEmptySeconds(ctx context.Context) (time.Duration, error)
+	SettingsPauseWhenEmptySecondsSet(ctx context.Context, duration time.Duration) (time.Duration, error)
+	SettingsPlayerIdleTimeout(ctx context.Context) (time.Duration, error)
+	SettingsPlayerIdleTimeoutSet(ctx context.Context, duration time.Duration) (time.Duration, error)
+	SettingsAllowFlight(ctx context.Context) (bool, error)
+	SettingsAllowFlightSet(ctx context.Context, allow bool) (bool, error)
+	SettingsMotd(ctx context.Context) (string, error)
+	SettingsMotdSet(ctx context.Context, motd string) (string, error)
+	SettingsSpawnProtectionRadius(ctx context.Context) (int, error)
+	SettingsSpawnProtectionRadiusSet(ctx context.Context, radius int) (int, error)
+	SettingsForceGameMode(ctx context.Context) (bool, error)
+	SettingsForceGameModeSet(ctx context.Context, forced bool) (bool, error)
+	SettingsGameMode(ctx context.Context) (string, error)
+	SettingsGameModeSet(ctx context.Context, gamemode string) (string, error)
+	SettingsDefaultGameMode(ctx context.Context) (GameMode, error)
+	SettingsDefaultGameModeSet(ctx context.Context, gamemode GameMode) (GameMode, error)
+	SettingsViewDistance(ctx context.Context) (int, error)
+	SettingsViewDistanceSet(ctx context.Context, distance int) (int, error)
+	SettingsSimulationDistance(ctx context.Context) (int, error)
+	SettingsSimulationDistanceSet(ctx context.Context, distance int) (int, error)
+	SettingsAcceptTransfers(ctx context.Context) (bool, error)
+	SettingsAcceptTransfersSet(ctx context.Context, accept bool) (bool, error)
+	SettingsStatusHeartbeatInterval(ctx context.Context) (time.Duration, error)
+	SettingsStatusHeartbeatIntervalSet(ctx context.Context, interval time.Duration) (time.Duration, error)
+	SettingsOperatorUserPermissionLevel(ctx context.Context) (int, error)
+	SettingsOperatorUserPermissionLevelSet(ctx context.Context, level int) (int, error)
+	SettingsHideOnlinePlayers(ctx context.Context) (bool, error)
+	SettingsHideOnlinePlayersSet(ctx context.Context, hide bool) (bool, error)
+	SettingsStatusReplies(ctx context.Context) (bool, error)
+	SettingsStatusRepliesSet(ctx context.Context, enabled bool) (bool, error)
+	SettingsEntityBroadcastRange(ctx context.Context) (int, error)
+	SettingsEntityBroadcastRangeSet(ctx context.Context, percentage_points int) (int, error)
+}
+
+// Notifier - server notification streams
+type Notifier interface {
+	NotifyPlayersJoined(ctx context.Context) <-chan Player
+	NotifyPlayersLeft(ctx context.Context) <-chan Player
+	NotifyServerStarted(ctx context.Context) <-chan struct{}
+	NotifyServerStopping(ctx context.Context) <-chan struct{}
+	NotifyServerSaving(ctx context.Context) <-chan struct{}
+	NotifyServerSaved(ctx context.Context) <-chan struct{}
+	NotifyServerStatus(ctx context.Context) <-chan ServerState
+	NotifyGamerulesUpdates(ctx context.Context) <-chan GameRule
+	NotifyOperatorsAdded(ctx context.Context) <-chan Operator
+	NotifyOperatorsRemoved(ctx context.Context) <-chan Operator
+	NotifyAllowlistAdded(ctx context.Context) <-chan Player
+	NotifyAllowlistRemoved(ctx context.Context) <-chan Player
+	NotifyIPBansAdded(ctx context.Context) <-chan IncomingIPBan
+	NotifyIPBansRemoved(ctx context.Context) <-chan IncomingIPBan
+	NotifyBansAdded(ctx context.Context) <-chan UserBan
+	NotifyBansRemoved(ctx context.Context) <-chan UserBan
+}
+
+// AccessListsAPI - the allowlist, ban and operator lists, as used by VanillaLists
+type AccessListsAPI interface {
+	AllowlistAPI
+	BansAPI
+	IPBansAPI
+	OperatorsAPI
+}
+
+// ManagementAPI - the complete management protocol client
+type ManagementAPI interface {
+	PlayersAPI
+	AllowlistAPI
+	BansAPI
+	IPBansAPI
+	OperatorsAPI
+	GamerulesAPI
+	ServerAPI
+	SettingsAPI
+	Notifier
+
+	Close() error
+}
+
+var _ ManagementAPI = (*RPCClient)(nil)
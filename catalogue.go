@@ -0,0 +1,317 @@
+package gomcsmp
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/eterline/go-mc-smp/internal/usage"
+)
+
+// Domain - a group of management protocol methods sharing the same root path.
+type Domain string
+
+const (
+	DomainPlayers        Domain = "players"
+	DomainAllowlist      Domain = "allowlist"
+	DomainBans           Domain = "bans"
+	DomainIPBans         Domain = "ip_bans"
+	DomainOperators      Domain = "operators"
+	DomainGamerules      Domain = "gamerules"
+	DomainServer         Domain = "server"
+	DomainServerSettings Domain = "serversettings"
+)
+
+// MethodKind - distinguishes callable methods from server notifications.
+type MethodKind string
+
+const (
+	KindMethod       MethodKind = "method"
+	KindNotification MethodKind = "notification"
+)
+
+// ProtocolMinVersion - the first server version exposing the management protocol.
+const ProtocolMinVersion = "1.21.9"
+
+// MethodInfo - describes a known management protocol method or notification.
+//
+// Params and Result are the Go types encoded and decoded by the client on the wire,
+// nil when the method takes no parameters or returns nothing.
+// For notifications Params is the type of the notification payload.
+type MethodInfo struct {
+	Name        string
+	Domain      Domain
+	Kind        MethodKind
+	Description string
+	ReadOnly    bool
+	Idempotent  bool
+	Params      reflect.Type
+	Result      reflect.Type
+	MinVersion  string
+}
+
+// Group - returns the MethodGroup used by client limits.
+func (m MethodInfo) Group() MethodGroup {
+	if m.ReadOnly {
+		return ReadMethods
+	}
+	return MutationMethods
+}
+
+// SupportedBy - reports whether a server of the given version exposes the method.
+// Unparsable versions are treated as supported.
+func (m MethodInfo) SupportedBy(v Version) bool {
+	return versionAtLeast(v, m.MinVersion)
+}
+
+// versionParts - parses "1.21" or "1.21.9" into numbers.
+func versionParts(name string) (parts [3]int, ok bool) {
+	sub := strings.Split(name, ".")
+	if len(sub) < 2 || len(sub) > 3 {
+		return parts, false
+	}
+
+	for i, s := range sub {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
+// versionAtLeast - reports whether v is the named version or newer.
+// Unparsable versions are treated as newer.
+func versionAtLeast(v Version, name string) bool {
+	have, ok := versionParts(v.Name)
+	if !ok {
+		return true
+	}
+	want, ok := versionParts(name)
+	if !ok {
+		return true
+	}
+	return slices.Compare(have[:], want[:]) >= 0
+}
+
+// ================
+
+// Methods - returns all known callable methods.
+func Methods() []MethodInfo {
+	return filterCatalogue(KindMethod)
+}
+
+// Notifications - returns all known server notifications.
+func Notifications() []MethodInfo {
+	return filterCatalogue(KindNotification)
+}
+
+// LookupMethod - finds a method or notification by its full name, e.g. "minecraft:players/kick".
+func LookupMethod(name string) (MethodInfo, bool) {
+	i, ok := catalogueIndex[name]
+	if !ok {
+		return MethodInfo{}, false
+	}
+	return catalogue[i], true
+}
+
+func filterCatalogue(kind MethodKind) []MethodInfo {
+	out := make([]MethodInfo, 0, len(catalogue))
+	for _, m := range catalogue {
+		if m.Kind == kind {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// ================
+
+var (
+	catalogue      = buildCatalogue()
+	catalogueIndex = indexCatalogue(catalogue)
+)
+
+func indexCatalogue(c []MethodInfo) map[string]int {
+	idx := make(map[string]int, len(c))
+	for i, m := range c {
+		idx[m.Name] = i
+	}
+	return idx
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func getter(domain Domain, path, desc string, result reflect.Type) MethodInfo {
+	return MethodInfo{
+		Name:        usage.NewMethod(string(domain)).Add(path).String(),
+		Domain:      domain,
+		Kind:        KindMethod,
+		Description: desc,
+		ReadOnly:    true,
+		Idempotent:  true,
+		Result:      result,
+		MinVersion:  ProtocolMinVersion,
+	}
+}
+
+func mutation(domain Domain, path, desc string, idempotent bool, params, result reflect.Type) MethodInfo {
+	return MethodInfo{
+		Name:        usage.NewMethod(string(domain)).Add(path).String(),
+		Domain:      domain,
+		Kind:        KindMethod,
+		Description: desc,
+		Idempotent:  idempotent,
+		Params:      params,
+		Result:      result,
+		MinVersion:  ProtocolMinVersion,
+	}
+}
+
+func notification(domain Domain, event, desc string, payload reflect.Type) MethodInfo {
+	return MethodInfo{
+		Name:        usage.NewMethod("notification").Add(string(domain)).Add(event).String(),
+		Domain:      domain,
+		Kind:        KindNotification,
+		Description: desc,
+		ReadOnly:    true,
+		Params:      payload,
+		MinVersion:  ProtocolMinVersion,
+	}
+}
+
+// catalogued methods; the client wrappers call them by these entries
+var (
+	methodPlayers     = getter(DomainPlayers, "", "Get all connected players", typeOf[PlayerRegistry]())
+	methodPlayersKick = mutation(DomainPlayers, "kick", "Kick players", false, typeOf[[]KickPlayer](), typeOf[[]Player]())
+
+	methodAllowlist       = getter(DomainAllowlist, "", "Get the allowlist", typeOf[PlayerRegistry]())
+	methodAllowlistSet    = mutation(DomainAllowlist, "set", "Set the allowlist to the provided list of players", true, typeOf[[]Player](), typeOf[[]Player]())
+	methodAllowlistAdd    = mutation(DomainAllowlist, "add", "Add players to the allowlist", false, typeOf[[]Player](), typeOf[[]Player]())
+	methodAllowlistRemove = mutation(DomainAllowlist, "remove", "Remove players from the allowlist", false, typeOf[[]Player](), typeOf[[]Player]())
+	methodAllowlistClear  = mutation(DomainAllowlist, "clear", "Clear all players in the allowlist", false, nil, typeOf[[]Player]())
+
+	methodBans       = getter(DomainBans, "", "Get the ban list", typeOf[[]UserBan]())
+	methodBansSet    = mutation(DomainBans, "set", "Set the banlist", true, typeOf[[]UserBan](), typeOf[[]UserBan]())
+	methodBansAdd    = mutation(DomainBans, "add", "Add players to the ban list", false, typeOf[[]UserBan](), typeOf[[]UserBan]())
+	methodBansRemove = mutation(DomainBans, "remove", "Remove players from ban list", false, typeOf[[]Player](), typeOf[[]UserBan]())
+	methodBansClear  = mutation(DomainBans, "clear", "Clear all players in ban list", false, nil, typeOf[[]UserBan]())
+
+	methodIPBans       = getter(DomainIPBans, "", "Get the ip ban list", typeOf[[]IPBan]())
+	methodIPBansSet    = mutation(DomainIPBans, "set", "Set the ip ban list", true, typeOf[[]IPBan](), typeOf[[]IPBan]())
+	methodIPBansAdd    = mutation(DomainIPBans, "add", "Add players to the ip ban list", false, typeOf[[]IPBan](), typeOf[[]IPBan]())
+	methodIPBansRemove = mutation(DomainIPBans, "remove", "Remove players from ip ban list", false, typeOf[[]IPBan](), typeOf[[]IPBan]())
+	methodIPBansClear  = mutation(DomainIPBans, "clear", "Clear all players in ip ban list", false, nil, typeOf[[]IPBan]())
+
+	methodOperators       = getter(DomainOperators, "", "Get all oped players", typeOf[[]Operator]())
+	methodOperatorsSet    = mutation(DomainOperators, "set", "Set all oped players", true, typeOf[[]Operator](), typeOf[[]Operator]())
+	methodOperatorsAdd    = mutation(DomainOperators, "add", "Op players", false, typeOf[[]Operator](), typeOf[[]Operator]())
+	methodOperatorsRemove = mutation(DomainOperators, "remove", "Deop players", false, typeOf[[]Player](), typeOf[[]Operator]())
+	methodOperatorsClear  = mutation(DomainOperators, "clear", "Deop all players", false, nil, typeOf[[]Operator]())
+
+	methodGamerules       = getter(DomainGamerules, "", "Get the available game rule keys and their current values", typeOf[GameRules]())
+	methodGamerulesUpdate = mutation(DomainGamerules, "update", "Update game rule value", true, typeOf[GameRule](), typeOf[GameRule]())
+
+	methodServerStatus        = getter(DomainServer, "status", "Get server status", typeOf[ServerState]())
+	methodServerSave          = mutation(DomainServer, "save", "Save server state", false, typeOf[bool](), typeOf[bool]())
+	methodServerStop          = mutation(DomainServer, "stop", "Stop server", false, nil, typeOf[bool]())
+	methodServerSystemMessage = mutation(DomainServer, "system_message", "Send a system message", false, typeOf[SystemMessage](), typeOf[bool]())
+)
+
+// catalogued notifications; the Notify streams subscribe by these entries
+var (
+	eventPlayersJoined    = notification(DomainPlayers, "joined", "Player joined", typeOf[Player]())
+	eventPlayersLeft      = notification(DomainPlayers, "left", "Player left", typeOf[Player]())
+	eventServerStarted    = notification(DomainServer, "started", "Server started", nil)
+	eventServerStopping   = notification(DomainServer, "stopping", "Server shutting down", nil)
+	eventServerSaving     = notification(DomainServer, "saving", "Server save started", nil)
+	eventServerSaved      = notification(DomainServer, "saved", "Server save completed", nil)
+	eventServerStatus     = notification(DomainServer, "status", "Server status heartbeat", typeOf[ServerState]())
+	eventGamerulesUpdated = notification(DomainGamerules, "updated", "Gamerule was changed", typeOf[GameRule]())
+	eventOperatorsAdded   = notification(DomainOperators, "added", "Player was oped", typeOf[Operator]())
+	eventOperatorsRemoved = notification(DomainOperators, "removed", "Player was deoped", typeOf[Operator]())
+	eventAllowlistAdded   = notification(DomainAllowlist, "added", "Player was added to allowlist", typeOf[Player]())
+	eventAllowlistRemoved = notification(DomainAllowlist, "removed", "Player was removed from allowlist", typeOf[Player]())
+	eventIPBansAdded      = notification(DomainIPBans, "added", "Ip was added to ip ban list", typeOf[IncomingIPBan]())
+	eventIPBansRemoved    = notification(DomainIPBans, "removed", "Ip was removed from ip ban list", typeOf[IncomingIPBan]())
+	eventBansAdded        = notification(DomainBans, "added", "Player was added to ban list", typeOf[UserBan]())
+	eventBansRemoved      = notification(DomainBans, "removed", "Player was removed from ban list", typeOf[UserBan]())
+)
+
+func buildCatalogue() []MethodInfo {
+	c := []MethodInfo{
+		methodPlayers, methodPlayersKick,
+
+		methodAllowlist, methodAllowlistSet, methodAllowlistAdd, methodAllowlistRemove, methodAllowlistClear,
+		methodBans, methodBansSet, methodBansAdd, methodBansRemove, methodBansClear,
+		methodIPBans, methodIPBansSet, methodIPBansAdd, methodIPBansRemove, methodIPBansClear,
+		methodOperators, methodOperatorsSet, methodOperatorsAdd, methodOperatorsRemove, methodOperatorsClear,
+
+		methodGamerules, methodGamerulesUpdate,
+
+		methodServerStatus, methodServerSave, methodServerStop, methodServerSystemMessage,
+	}
+
+	// setting entries take their names from the settings themselves
+	for _, s := range settingRegistry {
+		get := getter(DomainServerSettings, s.Path(), "Get "+s.Subject(), s.WireType())
+		get.Name = s.Method()
+		set := mutation(DomainServerSettings, s.Path()+"/set", "Set "+s.Subject(), true, s.WireType(), s.WireType())
+		set.Name = s.SetMethod()
+		c = append(c, get, set)
+	}
+
+	c = append(c,
+		eventPlayersJoined, eventPlayersLeft,
+		eventServerStarted, eventServerStopping, eventServerSaving, eventServerSaved, eventServerStatus,
+		eventGamerulesUpdated,
+		eventOperatorsAdded, eventOperatorsRemoved,
+		eventAllowlistAdded, eventAllowlistRemoved,
+		eventIPBansAdded, eventIPBansRemoved,
+		eventBansAdded, eventBansRemoved,
+	)
+
+	return c
+}
+
+// ================
+
+// methodGroupOf - classifies a method name into a MethodGroup.
+// Methods missing from the catalogue are classified by their last path segment.
+func methodGroupOf(method string) MethodGroup {
+	if m, ok := LookupMethod(method); ok {
+		return m.Group()
+	}
+
+	switch lastSegment(method) {
+	case "set", "add", "remove", "clear", "kick", "update", "save", "stop", "system_message":
+		return MutationMethods
+	}
+	return ReadMethods
+}
+
+// methodIdempotent - reports whether a method is safe to repeat:
+// getters and setters of absolute values.
+func methodIdempotent(method string) bool {
+	if m, ok := LookupMethod(method); ok {
+		return m.Idempotent
+	}
+
+	if methodGroupOf(method) == ReadMethods {
+		return true
+	}
+
+	switch lastSegment(method) {
+	case "set", "update":
+		return true
+	}
+	return false
+}
+
+func lastSegment(method string) string {
+	return method[strings.LastIndex(method, "/")+1:]
+}
@@ -0,0 +1,87 @@
+package gomcsmp_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+	"github.com/eterline/go-mc-smp/mcsmptest"
+)
+
+// TestWrappersCallCatalogue - every wrapper must call a catalogued method the fake serves.
+func TestWrappersCallCatalogue(t *testing.T) {
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+	ctx := context.Background()
+
+	steve := gomcsmp.NewPlayer("Steve")
+	ipBan := gomcsmp.NewPermanentIPBan(net.ParseIP("10.0.0.1"), "", "")
+	userBan := gomcsmp.NewPermanentUserBan(steve, "", "")
+	op := gomcsmp.NewOperator(steve, 4, false)
+
+	calls := []struct {
+		name string
+		call func() error
+	}{
+		{"PlayersGet", func() error { _, err := client.PlayersGet(ctx); return err }},
+		{"PlayersKick", func() error {
+			_, err := client.PlayersKick(ctx, gomcsmp.NewKickPlayer("Steve", gomcsmp.Message{}))
+			return err
+		}},
+		{"AllowlistGet", func() error { _, err := client.AllowlistGet(ctx); return err }},
+		{"AllowlistAdd", func() error { return client.AllowlistAdd(ctx, steve) }},
+		{"AllowlistRemove", func() error { return client.AllowlistRemove(ctx, steve) }},
+		{"AllowlistSet", func() error { return client.AllowlistSet(ctx) }},
+		{"AllowlistClear", func() error { return client.AllowlistClear(ctx) }},
+		{"BansGet", func() error { _, err := client.BansGet(ctx); return err }},
+		{"BansAdd", func() error { return client.BansAdd(ctx, userBan) }},
+		{"BansRemove", func() error { return client.BansRemove(ctx, steve) }},
+		{"BansSet", func() error { return client.BansSet(ctx) }},
+		{"BansClear", func() error { return client.BansClear(ctx) }},
+		{"IPBansGet", func() error { _, err := client.IPBansGet(ctx); return err }},
+		{"IPBansAdd", func() error { return client.IPBansAdd(ctx, ipBan) }},
+		{"IPBansRemove", func() error { return client.IPBansRemove(ctx, ipBan) }},
+		{"IPBansSet", func() error { return client.IPBansSet(ctx) }},
+		{"IPBansClear", func() error { return client.IPBansClear(ctx) }},
+		{"OperatorsGet", func() error { _, err := client.OperatorsGet(ctx); return err }},
+		{"OperatorsAdd", func() error { return client.OperatorsAdd(ctx, op) }},
+		{"OperatorsRemove", func() error { return client.OperatorsRemove(ctx, steve) }},
+		{"OperatorsSet", func() error { return client.OperatorsSet(ctx) }},
+		{"OperatorsClear", func() error { return client.OperatorsClear(ctx) }},
+		{"GamerulesGet", func() error { _, err := client.GamerulesGet(ctx); return err }},
+		{"ServerStatus", func() error { _, err := client.ServerStatus(ctx); return err }},
+		{"ServerSave", func() error { _, err := client.ServerSave(ctx, false); return err }},
+		{"ServerSystemMessage", func() error {
+			_, err := client.ServerSystemMessage(ctx, gomcsmp.SystemMessage{Message: gomcsmp.NewMessage("hi", "")})
+			return err
+		}},
+		{"ServerStop", func() error { _, err := client.ServerStop(ctx); return err }},
+	}
+
+	for _, s := range gomcsmp.AllSettings() {
+		calls = append(calls, struct {
+			name string
+			call func() error
+		}{s.Path(), func() error {
+			v, err := s.GetValue(ctx, client)
+			if err != nil {
+				return err
+			}
+			_, err = s.SetValue(ctx, client, v)
+			return err
+		}})
+	}
+
+	for _, c := range calls {
+		if err := c.call(); err != nil {
+			t.Errorf("%s: %v", c.name, err)
+		}
+	}
+
+	for _, name := range srv.Calls() {
+		if m, ok := gomcsmp.LookupMethod(name); !ok || m.Kind != gomcsmp.KindMethod {
+			t.Errorf("wrapper called %s which is not a catalogued method", name)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package gomcsmp
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
@@ -17,6 +18,15 @@ type clientConfig struct {
 	path        string
 	tls         bool
 	callTimeout time.Duration
+	tracer      Tracer
+	limits      Limits
+	groupLimits map[MethodGroup]Limits
+	limitWait   time.Duration
+	retry       RetryPolicy
+	recorder    *Recorder
+	resolver    ProfileResolver
+
+	customGameRules map[GameRuleKey]bool
 }
 
 func defaultClientConfig() *clientConfig {
@@ -24,6 +34,7 @@ func defaultClientConfig() *clientConfig {
 		path:        "/",
 		tls:         false,
 		callTimeout: 5 * time.Second,
+		tracer:      NoopTracer{},
 	}
 }
 
@@ -53,11 +64,35 @@ func WithCallTimeout(t time.Duration) ClientOption {
 	}
 }
 
+// WithTracer - opens a span through the given Tracer for every RPC call.
+// Spans are nested under the span carried in the call context.
+func WithTracer(t Tracer) ClientOption {
+	return func(cfg *clientConfig) {
+		if t == nil {
+			return
+		}
+		cfg.tracer = t
+	}
+}
+
 // ================
 
+// transport - the connection RPCClient performs calls over.
+type transport interface {
+	CallWithContext(ctx context.Context, method string, params ...any) (*jsonrpc.RPCResponse, error)
+	Notifications() <-chan *jsonrpc.RPCResponse
+	Close() error
+}
+
 type RPCClient struct {
-	core   *jsonrpc.JsonRPCClient
-	notify *notificationPipe
+	core     transport
+	notify   *notificationPipe
+	tracer   Tracer
+	limiter  *callLimiter
+	retry    RetryPolicy
+	resolver ProfileResolver
+
+	customGameRules map[GameRuleKey]bool
 }
 
 func NewClient(host string, port uint16, token string, opts ...ClientOption) (*RPCClient, error) {
@@ -77,25 +112,107 @@ func NewClient(host string, port uint16, token string, opts ...ClientOption) (*R
 		Path:   cfg.path,
 	}
 
-	core, err := jsonrpc.NewJsonRPCClient(u.String(), token, cfg.callTimeout)
+	var dialOpts []jsonrpc.DialOption
+	if cfg.recorder != nil {
+		// the session header goes first and the observer is installed
+		// before the read loop starts, so no early frame is missed
+		cfg.recorder.session(u.String())
+		dialOpts = append(dialOpts, jsonrpc.WithObserver(cfg.recorder.observer(token)))
+	}
+
+	core, err := jsonrpc.NewJsonRPCClient(u.String(), token, cfg.callTimeout, dialOpts...)
 	if err != nil {
 		return nil, err
 	}
 
+	return newRPCClient(core, cfg), nil
+}
+
+func newRPCClient(core transport, cfg *clientConfig) *RPCClient {
 	client := &RPCClient{
-		core:   core,
-		notify: newNotificationPipe(),
+		core:     core,
+		notify:   newNotificationPipe(),
+		tracer:   cfg.tracer,
+		limiter:  newCallLimiter(cfg),
+		retry:    cfg.retry,
+		resolver: cfg.resolver,
+
+		customGameRules: cfg.customGameRules,
 	}
 
 	go client.poolNotifications()
 
-	return client, nil
+	return client
 }
 
 func (rpc *RPCClient) Close() error {
 	return rpc.core.Close()
 }
 
+// call - performs an RPC call wrapped in a tracing span.
+// Idempotent methods are repeated on transient failures according to the retry policy.
+func (rpc *RPCClient) call(ctx context.Context, method string, params ...any) (*jsonrpc.RPCResponse, error) {
+	ctx, span := rpc.tracer.Start(ctx, method, Attr("rpc.method", method))
+	defer span.End()
+
+	retry := rpc.retry.enabled() && methodIdempotent(method)
+
+	var (
+		r        *jsonrpc.RPCResponse
+		err      error
+		attempts int
+	)
+
+	for {
+		attempts++
+		r, err = rpc.attempt(ctx, method, params...)
+		if err == nil || !retry || attempts >= rpc.retry.MaxAttempts || !transientError(ctx, err) {
+			break
+		}
+		if rpc.retry.sleep(ctx, attempts) != nil {
+			break
+		}
+	}
+
+	if retry {
+		span.SetAttributes(Attr("rpc.attempts", attempts))
+	}
+
+	if err != nil {
+		if retry {
+			err = &RetryError{Method: method, Attempts: attempts, Err: err}
+		}
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if err := r.Err(); err != nil {
+		span.RecordError(err)
+	}
+
+	return r, nil
+}
+
+// send - performs an RPC call whose result is not needed and returns only its error.
+func (rpc *RPCClient) send(ctx context.Context, method string, params ...any) error {
+	r, err := rpc.call(ctx, method, params...)
+	if err != nil {
+		return err
+	}
+	return r.Err()
+}
+
+// attempt - performs a single RPC call after passing the client limits.
+func (rpc *RPCClient) attempt(ctx context.Context, method string, params ...any) (*jsonrpc.RPCResponse, error) {
+	release, err := rpc.limiter.acquire(ctx, method)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return rpc.core.CallWithContext(ctx, method, params...)
+}
+
 func (rpc *RPCClient) poolNotifications() {
 	defer rpc.notify.Close()
 	for n := range rpc.core.Notifications() {
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"net"
 	"net/netip"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -13,70 +15,265 @@ import (
 	"github.com/google/uuid"
 )
 
+// PlayerRegistry - a set of players indexed by UUID and by case-insensitive name.
+//
+// Two entries are the same player when both have a UUID and the UUIDs match,
+// otherwise when their names match ignoring case. Adding a player with a known
+// UUID under a new name replaces the old name, so renames do not leave duplicates.
+// Iteration is sorted by name, then by UUID.
 type PlayerRegistry struct {
-	data map[string]*uuid.UUID
+	byID   map[uuid.UUID]*Player
+	byName map[string]*Player
 }
 
 func newPlayerRegistry(len int) *PlayerRegistry {
 	return &PlayerRegistry{
-		data: make(map[string]*uuid.UUID, len),
+		byID:   make(map[uuid.UUID]*Player, len),
+		byName: make(map[string]*Player, len),
 	}
 }
 
 func NewPlayerRegistry(players []Player) *PlayerRegistry {
-	r := &PlayerRegistry{
-		data: make(map[string]*uuid.UUID, len(players)),
-	}
-
+	r := newPlayerRegistry(len(players))
 	for _, p := range players {
-		if p.Name == "" {
-			continue
-		}
-		r.data[p.Name] = p.ID
+		r.Add(p)
 	}
-
 	return r
 }
 
 func NewPlayerRegistryNames(name ...string) *PlayerRegistry {
 	r := newPlayerRegistry(len(name))
+	for _, n := range name {
+		r.Add(NewPlayer(n))
+	}
+	return r
+}
 
-	for _, p := range name {
-		if p == "" {
-			continue
+// playerID - returns the UUID of the player, ok is false when it is absent or nil.
+func playerID(p Player) (id uuid.UUID, ok bool) {
+	if p.ID == nil || *p.ID == uuid.Nil {
+		return uuid.Nil, false
+	}
+	return *p.ID, true
+}
+
+func playerNameKey(name string) string {
+	return strings.ToLower(name)
+}
+
+// find - returns the entry matching the player.
+func (r *PlayerRegistry) find(p Player) *Player {
+	if r == nil {
+		return nil
+	}
+
+	id, hasID := playerID(p)
+	if hasID {
+		if e, ok := r.byID[id]; ok {
+			return e
 		}
-		r.data[p] = nil
 	}
 
-	return r
+	if p.Name == "" {
+		return nil
+	}
+
+	e, ok := r.byName[playerNameKey(p.Name)]
+	if !ok {
+		return nil
+	}
+	if _, entryHasID := playerID(*e); hasID && entryHasID {
+		return nil
+	}
+	return e
+}
+
+func (r *PlayerRegistry) init() {
+	if r.byID == nil {
+		r.byID = map[uuid.UUID]*Player{}
+	}
+	if r.byName == nil {
+		r.byName = map[string]*Player{}
+	}
+}
+
+// ============
+
+// Add - adds the player or updates the matching entry.
+// A UUID fills in a name-only entry, a name replaces the old name of the UUID.
+// Players without both name and UUID are ignored.
+func (r *PlayerRegistry) Add(player Player) {
+	id, hasID := playerID(player)
+	if player.Name == "" && !hasID {
+		return
+	}
+	r.init()
+
+	e := r.find(player)
+	if e == nil {
+		e = &Player{}
+	}
+
+	if hasID {
+		e.ID = &id
+		r.byID[id] = e
+	}
+
+	if player.Name != "" {
+		if old := playerNameKey(e.Name); old != playerNameKey(player.Name) && r.byName[old] == e {
+			delete(r.byName, old)
+		}
+		e.Name = player.Name
+
+		key := playerNameKey(player.Name)
+		if prev, ok := r.byName[key]; ok && prev != e {
+			// the name moved to another UUID or a name-only entry was merged
+			if _, prevHasID := playerID(*prev); !prevHasID {
+				r.removeEntry(prev)
+			}
+		}
+		r.byName[key] = e
+	}
+}
+
+// Remove - removes the matching player, reporting whether it was present.
+func (r *PlayerRegistry) Remove(player Player) bool {
+	e := r.find(player)
+	if e == nil {
+		return false
+	}
+	r.removeEntry(e)
+	return true
+}
+
+func (r *PlayerRegistry) removeEntry(e *Player) {
+	if id, ok := playerID(*e); ok && r.byID[id] == e {
+		delete(r.byID, id)
+	}
+	if key := playerNameKey(e.Name); r.byName[key] == e {
+		delete(r.byName, key)
+	}
+}
+
+// Len - returns the number of players in the registry.
+func (r *PlayerRegistry) Len() int {
+	if r == nil {
+		return 0
+	}
+
+	n := len(r.byID)
+	for _, e := range r.byName {
+		if _, ok := playerID(*e); !ok {
+			n++
+		}
+	}
+	return n
+}
+
+// Online - returns the number of players in the registry.
+//
+// Deprecated: use Len.
+func (r *PlayerRegistry) Online() int {
+	return r.Len()
+}
+
+// Contains - reports whether a player with the name is present, ignoring case.
+func (r *PlayerRegistry) Contains(name string) bool {
+	_, ok := r.PlayerByName(name)
+	return ok
+}
+
+// ContainsPlayer - reports whether the player is present, matching by UUID or name.
+func (r *PlayerRegistry) ContainsPlayer(player Player) bool {
+	return r.find(player) != nil
 }
 
+func (r *PlayerRegistry) UUIDByName(name string) (id uuid.UUID, ok bool) {
+	p, ok := r.PlayerByName(name)
+	if !ok {
+		return uuid.Nil, false
+	}
+	return playerID(p)
+}
+
+// PlayerByName - returns the player with the name, ignoring case.
+func (r *PlayerRegistry) PlayerByName(name string) (player Player, ok bool) {
+	if r == nil {
+		return Player{}, false
+	}
+
+	e, ok := r.byName[playerNameKey(name)]
+	if !ok {
+		return Player{}, false
+	}
+	return *e, true
+}
+
+// PlayerByID - returns the player with the UUID.
+func (r *PlayerRegistry) PlayerByID(id uuid.UUID) (player Player, ok bool) {
+	if r == nil {
+		return Player{}, false
+	}
+
+	e, ok := r.byID[id]
+	if !ok {
+		return Player{}, false
+	}
+	return *e, true
+}
+
+// ============
+
+// Players - returns the players sorted by name, then by UUID.
 func (r *PlayerRegistry) Players() []Player {
-	if r == nil || len(r.data) == 0 {
+	if r.Len() == 0 {
 		return nil
 	}
 
-	players := make([]Player, 0, len(r.data))
-
-	for name, id := range r.data {
-		players = append(players, Player{
-			Name: name,
-			ID:   id,
-		})
+	players := make([]Player, 0, r.Len())
+	for _, e := range r.byID {
+		players = append(players, *e)
+	}
+	for _, e := range r.byName {
+		if _, ok := playerID(*e); !ok {
+			players = append(players, *e)
+		}
 	}
 
+	slices.SortFunc(players, comparePlayers)
 	return players
 }
 
+func comparePlayers(a, b Player) int {
+	if c := strings.Compare(playerNameKey(a.Name), playerNameKey(b.Name)); c != 0 {
+		return c
+	}
+	aID, _ := playerID(a)
+	bID, _ := playerID(b)
+	return strings.Compare(aID.String(), bID.String())
+}
+
+// All - iterates over the players in Players order.
+func (r *PlayerRegistry) All() iter.Seq[Player] {
+	return func(yield func(Player) bool) {
+		for _, p := range r.Players() {
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// IDs - returns the known UUIDs in Players order.
 func (r *PlayerRegistry) IDs() []uuid.UUID {
-	if r == nil || len(r.data) == 0 {
+	if r.Len() == 0 {
 		return nil
 	}
 
 	ids := []uuid.UUID{}
-	for _, id := range r.data {
-		if id != nil && *id != uuid.Nil {
-			ids = append(ids, *id)
+	for p := range r.All() {
+		if id, ok := playerID(p); ok {
+			ids = append(ids, id)
 		}
 	}
 	return ids
@@ -92,17 +289,13 @@ func (r *PlayerRegistry) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	r.data = make(map[string]*uuid.UUID, len(players))
-	for _, p := range players {
-		r.data[p.Name] = p.ID
-	}
-
+	*r = *NewPlayerRegistry(players)
 	return nil
 }
 
 func (r *PlayerRegistry) Filter(f func(data Player) bool) *PlayerRegistry {
 	newR := newPlayerRegistry(0)
-	for _, player := range r.Players() {
+	for player := range r.All() {
 		if f(player) {
 			newR.Add(player)
 		}
@@ -112,44 +305,51 @@ func (r *PlayerRegistry) Filter(f func(data Player) bool) *PlayerRegistry {
 
 // ============
 
-func (r *PlayerRegistry) Add(player Player) {
-	r.data[player.Name] = player.ID
+// Union - returns a registry of the players present in r or in other.
+// Entries of other update matching entries of r.
+func (r *PlayerRegistry) Union(other *PlayerRegistry) *PlayerRegistry {
+	u := NewPlayerRegistry(r.Players())
+	for p := range other.All() {
+		u.Add(p)
+	}
+	return u
 }
 
-func (r *PlayerRegistry) Online() int {
-	return len(r.data)
+// Difference - returns a registry of the players of r absent from other.
+func (r *PlayerRegistry) Difference(other *PlayerRegistry) *PlayerRegistry {
+	return r.Filter(func(p Player) bool {
+		return !other.ContainsPlayer(p)
+	})
 }
 
-func (r *PlayerRegistry) Contains(name string) bool {
-	_, ok := r.data[name]
-	return ok
-}
-
-func (r *PlayerRegistry) UUIDByName(name string) (id uuid.UUID, ok bool) {
-	srcId, ok := r.data[name]
-	if !ok {
-		return uuid.Nil, false
-	}
-
-	if srcId == nil {
-		return uuid.Nil, false
+// Intersect - returns a registry of the players present in both r and other.
+// Entries missing a UUID are completed from the matching entry of other.
+func (r *PlayerRegistry) Intersect(other *PlayerRegistry) *PlayerRegistry {
+	out := newPlayerRegistry(0)
+	for p := range r.All() {
+		match := other.find(p)
+		if match == nil {
+			continue
+		}
+		if _, ok := playerID(p); !ok {
+			p.ID = match.ID
+		}
+		out.Add(p)
 	}
-
-	return *srcId, true
+	return out
 }
 
-func (r *PlayerRegistry) PlayerByName(name string) (player Player, ok bool) {
-	srcId, ok := r.data[name]
-	if !ok {
-		return Player{}, false
+// Equal - reports whether both registries hold the same players.
+func (r *PlayerRegistry) Equal(other *PlayerRegistry) bool {
+	if r.Len() != other.Len() {
+		return false
 	}
-
-	player = Player{
-		Name: name,
-		ID:   srcId,
+	for p := range r.All() {
+		if !other.ContainsPlayer(p) {
+			return false
+		}
 	}
-
-	return player, true
+	return true
 }
 
 // ============
@@ -280,11 +480,11 @@ func (gr GameRule) Integer() (int, error) {
 // IncomingIPBan - represents an incoming IP ban request or record.
 // Contains the reason, expiration time, IP address, source of ban, and the associated player.
 type IncomingIPBan struct {
-	Reason  string `json:"reason"`
-	Expires string `json:"expires"`
-	IP      string `json:"ip"`
-	Source  string `json:"source"`
-	Player  Player `json:"player"`
+	Reason  string    `json:"reason"`
+	Expires BanExpiry `json:"expires"`
+	IP      string    `json:"ip"`
+	Source  string    `json:"source"`
+	Player  Player    `json:"player"`
 }
 
 // Addr - parses the IP field into a netip.Addr.
@@ -307,21 +507,17 @@ func (iipb IncomingIPBan) NetIP() (net.IP, error) {
 	return a, nil
 }
 
-// ExpireIn - parses the Expires field into a time.Time object.
-// Returns zero time (time.Time{}) if parsing fails.
+// ExpireIn - returns the time the ban is lifted.
+// Returns zero time (time.Time{}) for permanent bans.
 func (iipb IncomingIPBan) ExpireIn() time.Time {
-	t, err := time.Parse(time.ANSIC, iipb.Expires)
-	if err != nil {
-		return time.Time{}
-	}
+	t, _ := iipb.Expires.Time()
 	return t
 }
 
 // Expired - checks if the ban has already expired.
-// Returns true if Expires is valid and the current time is after it.
+// Permanent bans never expire.
 func (iipb IncomingIPBan) Expired() bool {
-	t := iipb.ExpireIn()
-	return !t.IsZero() && time.Now().After(t)
+	return iipb.Expires.Expired()
 }
 
 // ============
@@ -340,6 +536,22 @@ func NewMessage(literal string, translatable string, params ...string) Message {
 	}
 }
 
+// NewComponentMessage - creates a Message from a rich text component.
+// The protocol message only carries literal and translatable text, so colors and
+// formats are written as § codes: Literal holds the legacy rendering, and a
+// translatable root keeps its key with legacy rendered parameters.
+// Click and hover events and fonts cannot be expressed and are lost.
+func NewComponentMessage(c TextComponent) Message {
+	m := Message{Literal: RenderLegacy(c, LegacySection)}
+	if c.Translate != "" {
+		m.Translatable = c.Translate
+		for _, arg := range c.With {
+			m.TranslatableParams = append(m.TranslatableParams, RenderLegacy(arg, LegacySection))
+		}
+	}
+	return m
+}
+
 // ============
 
 type SystemMessage struct {
@@ -367,23 +579,41 @@ func NewKickPlayer(name string, msg Message) KickPlayer {
 // IPBan - represents an IP ban record.
 // Contains the reason, expiration time, IP address, and the source of the ban.
 type IPBan struct {
-	Reason  string `json:"reason"`
-	Expires string `json:"expires"`
-	IP      string `json:"ip"`
-	Source  string `json:"source"`
+	Reason  string    `json:"reason"`
+	Expires BanExpiry `json:"expires"`
+	IP      string    `json:"ip"`
+	Source  string    `json:"source"`
+
+	// Created - the creation date kept from banned-ips.json, it is not sent to the server.
+	Created string `json:"-"`
 }
 
 // NewIPBan - creates a new IPBan with the given parameters.
+// A zero expires time creates a permanent ban.
 func NewIPBan(ip net.IP, expires time.Time, reason, source string) (IPBan, error) {
+	return newIPBan(ip, ExpiresAt(expires), reason, source)
+}
 
-	if expires.Before(time.Now()) {
-		return IPBan{}, errors.New("invalid expiration time")
+// NewPermanentIPBan - creates a new IPBan which never expires.
+func NewPermanentIPBan(ip net.IP, reason, source string) IPBan {
+	ban, _ := newIPBan(ip, Permanent(), reason, source)
+	return ban
+}
+
+// NewTempIPBan - creates a new IPBan lifted after the given duration.
+func NewTempIPBan(ip net.IP, d time.Duration, reason, source string) (IPBan, error) {
+	return newIPBan(ip, ExpiresAfter(d), reason, source)
+}
+
+func newIPBan(ip net.IP, expires BanExpiry, reason, source string) (IPBan, error) {
+	if err := expires.validate(); err != nil {
+		return IPBan{}, err
 	}
 
 	return IPBan{
 		IP:      ip.String(),
 		Reason:  reason,
-		Expires: expires.Format(time.ANSIC),
+		Expires: expires,
 		Source:  source,
 	}, nil
 }
@@ -408,21 +638,17 @@ func (ipb IPBan) NetIP() (net.IP, error) {
 	return a, nil
 }
 
-// ExpireIn - parses the Expires field into a time.Time object.
-// Returns zero time (time.Time{}) if parsing fails.
+// ExpireIn - returns the time the ban is lifted.
+// Returns zero time (time.Time{}) for permanent bans.
 func (ipb IPBan) ExpireIn() time.Time {
-	t, err := time.Parse(time.ANSIC, ipb.Expires)
-	if err != nil {
-		return time.Time{}
-	}
+	t, _ := ipb.Expires.Time()
 	return t
 }
 
 // Expired - checks if the ban has already expired.
-// Returns true if Expires is valid and the current time is after it.
+// Permanent bans never expire.
 func (ipb IPBan) Expired() bool {
-	t := ipb.ExpireIn()
-	return !t.IsZero() && time.Now().After(t)
+	return ipb.Expires.Expired()
 }
 
 // ============
@@ -430,42 +656,56 @@ func (ipb IPBan) Expired() bool {
 // UserBan - represents a user ban record.
 // Contains the reason, expiration time, source of the ban, and the banned player.
 type UserBan struct {
-	Reason  string `json:"reason"`
-	Expires string `json:"expires"`
-	Source  string `json:"source"`
-	Player  Player `json:"player"`
+	Reason  string    `json:"reason"`
+	Expires BanExpiry `json:"expires"`
+	Source  string    `json:"source"`
+	Player  Player    `json:"player"`
+
+	// Created - the creation date kept from banned-players.json, it is not sent to the server.
+	Created string `json:"-"`
 }
 
 // NewUserBan - creates a new UserBan with the given parameters.
+// A zero expires time creates a permanent ban.
 func NewUserBan(player Player, expires time.Time, reason, source string) (UserBan, error) {
+	return newUserBan(player, ExpiresAt(expires), reason, source)
+}
+
+// NewPermanentUserBan - creates a new UserBan which never expires.
+func NewPermanentUserBan(player Player, reason, source string) UserBan {
+	ban, _ := newUserBan(player, Permanent(), reason, source)
+	return ban
+}
 
-	if expires.Before(time.Now()) {
-		return UserBan{}, errors.New("invalid expiration time")
+// NewTempUserBan - creates a new UserBan lifted after the given duration.
+func NewTempUserBan(player Player, d time.Duration, reason, source string) (UserBan, error) {
+	return newUserBan(player, ExpiresAfter(d), reason, source)
+}
+
+func newUserBan(player Player, expires BanExpiry, reason, source string) (UserBan, error) {
+	if err := expires.validate(); err != nil {
+		return UserBan{}, err
 	}
 
 	return UserBan{
 		Player:  player,
 		Reason:  reason,
-		Expires: expires.Format(time.ANSIC),
+		Expires: expires,
 		Source:  source,
 	}, nil
 }
 
-// ExpireIn - parses the Expires field into a time.Time object.
-// Returns zero time (time.Time{}) if parsing fails.
+// ExpireIn - returns the time the ban is lifted.
+// Returns zero time (time.Time{}) for permanent bans.
 func (ub UserBan) ExpireIn() time.Time {
-	t, err := time.Parse(time.ANSIC, ub.Expires)
-	if err != nil {
-		return time.Time{}
-	}
+	t, _ := ub.Expires.Time()
 	return t
 }
 
 // Expired - checks if the user ban has already expired.
-// Returns true if Expires is valid and the current time is after it.
+// Permanent bans never expire.
 func (ub UserBan) Expired() bool {
-	t := ub.ExpireIn()
-	return !t.IsZero() && time.Now().After(t)
+	return ub.Expires.Expired()
 }
 
 // ============
@@ -0,0 +1,152 @@
+package gomcsmp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidDifficulty = errors.New("invalid difficulty")
+	ErrInvalidGameMode   = errors.New("invalid game mode")
+)
+
+// ============
+
+// Difficulty - the server difficulty level.
+type Difficulty string
+
+const (
+	DifficultyPeaceful Difficulty = "peaceful"
+	DifficultyEasy     Difficulty = "easy"
+	DifficultyNormal   Difficulty = "normal"
+	DifficultyHard     Difficulty = "hard"
+)
+
+// Difficulties - returns all valid difficulty levels in ascending order.
+func Difficulties() []Difficulty {
+	return []Difficulty{DifficultyPeaceful, DifficultyEasy, DifficultyNormal, DifficultyHard}
+}
+
+// ParseDifficulty - parses a difficulty name, ignoring case and surrounding spaces.
+// The legacy numeric ids 0-3 are accepted as well.
+func ParseDifficulty(s string) (Difficulty, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+
+	for i, d := range Difficulties() {
+		if v == string(d) || v == fmt.Sprint(i) {
+			return d, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: '%s'", ErrInvalidDifficulty, s)
+}
+
+// Valid - reports whether d is one of the known difficulty levels.
+func (d Difficulty) Valid() bool {
+	_, err := d.validate()
+	return err == nil
+}
+
+func (d Difficulty) validate() (Difficulty, error) {
+	for _, known := range Difficulties() {
+		if d == known {
+			return d, nil
+		}
+	}
+	return "", fmt.Errorf("%w: '%s'", ErrInvalidDifficulty, string(d))
+}
+
+func (d Difficulty) String() string {
+	return string(d)
+}
+
+// MarshalText - encodes the difficulty, failing for unknown values.
+// Used by encoding/json as well.
+func (d Difficulty) MarshalText() ([]byte, error) {
+	v, err := d.validate()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(v), nil
+}
+
+// UnmarshalText - decodes the difficulty with ParseDifficulty.
+func (d *Difficulty) UnmarshalText(b []byte) error {
+	v, err := ParseDifficulty(string(b))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// ============
+
+// GameMode - a player game mode.
+type GameMode string
+
+const (
+	GameModeSurvival  GameMode = "survival"
+	GameModeCreative  GameMode = "creative"
+	GameModeAdventure GameMode = "adventure"
+	GameModeSpectator GameMode = "spectator"
+)
+
+// GameModes - returns all valid game modes ordered by their legacy numeric id.
+func GameModes() []GameMode {
+	return []GameMode{GameModeSurvival, GameModeCreative, GameModeAdventure, GameModeSpectator}
+}
+
+// ParseGameMode - parses a game mode name, ignoring case and surrounding spaces.
+// The legacy numeric ids 0-3 are accepted as well.
+func ParseGameMode(s string) (GameMode, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+
+	for i, m := range GameModes() {
+		if v == string(m) || v == fmt.Sprint(i) {
+			return m, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: '%s'", ErrInvalidGameMode, s)
+}
+
+// Valid - reports whether m is one of the known game modes.
+func (m GameMode) Valid() bool {
+	_, err := m.validate()
+	return err == nil
+}
+
+func (m GameMode) validate() (GameMode, error) {
+	for _, known := range GameModes() {
+		if m == known {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("%w: '%s'", ErrInvalidGameMode, string(m))
+}
+
+func (m GameMode) String() string {
+	return string(m)
+}
+
+// MarshalText - encodes the game mode, failing for unknown values.
+// Used by encoding/json as well.
+func (m GameMode) MarshalText() ([]byte, error) {
+	v, err := m.validate()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(v), nil
+}
+
+// UnmarshalText - decodes the game mode with ParseGameMode.
+func (m *GameMode) UnmarshalText(b []byte) error {
+	v, err := ParseGameMode(string(b))
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
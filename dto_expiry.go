@@ -0,0 +1,133 @@
+package gomcsmp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidBanExpiry = errors.New("invalid ban expiry")
+
+// BanExpiryLayout - the time layout vanilla uses for ban expiry dates.
+const BanExpiryLayout = "2006-01-02 15:04:05 -0700"
+
+// banExpiryForever - the value vanilla writes for permanent bans.
+const banExpiryForever = "forever"
+
+// banExpiryLayouts - accepted time layouts, tried in order.
+var banExpiryLayouts = []string{
+	BanExpiryLayout,
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02 15:04:05Z07:00",
+	time.DateTime,
+	time.ANSIC,
+	time.UnixDate,
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// BanExpiry - the expiry of a ban, either permanent or timed.
+// The zero value is a permanent ban.
+type BanExpiry struct {
+	at time.Time
+}
+
+// Permanent - returns the expiry of a ban which never expires.
+func Permanent() BanExpiry {
+	return BanExpiry{}
+}
+
+// ExpiresAt - returns the expiry of a ban lifted at t.
+// A zero t yields a permanent ban.
+func ExpiresAt(t time.Time) BanExpiry {
+	return BanExpiry{at: t.Truncate(time.Second)}
+}
+
+// ExpiresAfter - returns the expiry of a ban lifted after d from now.
+func ExpiresAfter(d time.Duration) BanExpiry {
+	return ExpiresAt(time.Now().Add(d))
+}
+
+// ParseBanExpiry - parses an expiry as seen on the wire and in vanilla ban files.
+// Empty values and "forever" are permanent, timed values are accepted
+// in the vanilla layout, RFC 3339 and a few other common layouts.
+func ParseBanExpiry(s string) (BanExpiry, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.EqualFold(s, banExpiryForever) {
+		return Permanent(), nil
+	}
+
+	for _, layout := range banExpiryLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return ExpiresAt(t), nil
+		}
+	}
+
+	return BanExpiry{}, fmt.Errorf("%w: '%s'", ErrInvalidBanExpiry, s)
+}
+
+// IsPermanent - reports whether the ban never expires.
+func (e BanExpiry) IsPermanent() bool {
+	return e.at.IsZero()
+}
+
+// Time - returns the time the ban is lifted, ok is false for permanent bans.
+func (e BanExpiry) Time() (t time.Time, ok bool) {
+	return e.at, !e.at.IsZero()
+}
+
+// Remaining - returns the time left until the ban is lifted.
+// Returns a negative duration for expired bans and 0 for permanent ones.
+func (e BanExpiry) Remaining() time.Duration {
+	if e.IsPermanent() {
+		return 0
+	}
+	return time.Until(e.at)
+}
+
+// Expired - reports whether a timed ban has already been lifted.
+func (e BanExpiry) Expired() bool {
+	return !e.IsPermanent() && !time.Now().Before(e.at)
+}
+
+// String - returns the expiry in the vanilla format, "forever" for permanent bans.
+func (e BanExpiry) String() string {
+	if e.IsPermanent() {
+		return banExpiryForever
+	}
+	return e.at.Format(BanExpiryLayout)
+}
+
+func (e BanExpiry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON - accepts null, an empty string, "forever" and timed values.
+func (e *BanExpiry) UnmarshalJSON(b []byte) error {
+	var s *string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBanExpiry, err)
+	}
+	if s == nil {
+		*e = Permanent()
+		return nil
+	}
+
+	v, err := ParseBanExpiry(*s)
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+
+// validate - rejects timed expiries which are already in the past.
+func (e BanExpiry) validate() error {
+	if e.Expired() {
+		return fmt.Errorf("%w: %s is in the past", ErrInvalidBanExpiry, e)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package gomcsmp_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+)
+
+func TestBanExpiryMarshal(t *testing.T) {
+	at := time.Date(2030, 5, 1, 12, 30, 0, 0, time.FixedZone("", 3*60*60))
+
+	tests := []struct {
+		name   string
+		expiry gomcsmp.BanExpiry
+		want   string
+	}{
+		{"zero value", gomcsmp.BanExpiry{}, `"forever"`},
+		{"permanent", gomcsmp.Permanent(), `"forever"`},
+		{"zero time", gomcsmp.ExpiresAt(time.Time{}), `"forever"`},
+		{"timed", gomcsmp.ExpiresAt(at), `"2030-05-01 12:30:00 +0300"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.expiry)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if string(b) != tt.want {
+				t.Fatalf("Marshal = %s, want %s", b, tt.want)
+			}
+		})
+	}
+
+	ban := gomcsmp.UserBan{Player: gomcsmp.NewPlayer("Steve")}
+	b, err := json.Marshal(ban)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(b), `"expires":"forever"`) {
+		t.Fatalf("permanent ban = %s, want expires forever", b)
+	}
+}
+
+func TestBanExpiryUnmarshal(t *testing.T) {
+	at := time.Date(2030, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		in        string
+		permanent bool
+		at        time.Time
+	}{
+		{"null", `null`, true, time.Time{}},
+		{"empty", `""`, true, time.Time{}},
+		{"forever", `"forever"`, true, time.Time{}},
+		{"forever upper case", `" FOREVER "`, true, time.Time{}},
+		{"vanilla", `"2030-05-01 12:30:00 +0000"`, false, at},
+		{"vanilla offset", `"2030-05-01 15:30:00 +0300"`, false, at},
+		{"rfc3339", `"2030-05-01T12:30:00Z"`, false, at},
+		{"rfc3339 fraction", `"2030-05-01T12:30:00.75Z"`, false, at},
+		{"date time", `"2030-05-01 12:30:00"`, false, at},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e gomcsmp.BanExpiry
+			if err := json.Unmarshal([]byte(tt.in), &e); err != nil {
+				t.Fatalf("Unmarshal(%s): %v", tt.in, err)
+			}
+			if e.IsPermanent() != tt.permanent {
+				t.Fatalf("Unmarshal(%s) permanent = %v, want %v", tt.in, e.IsPermanent(), tt.permanent)
+			}
+			if got, _ := e.Time(); !got.Equal(tt.at) {
+				t.Fatalf("Unmarshal(%s) time = %v, want %v", tt.in, got, tt.at)
+			}
+		})
+	}
+
+	for _, in := range []string{`"tomorrow"`, `42`, `"2030-13-01 00:00:00"`} {
+		var e gomcsmp.BanExpiry
+		if err := json.Unmarshal([]byte(in), &e); !errors.Is(err, gomcsmp.ErrInvalidBanExpiry) {
+			t.Fatalf("Unmarshal(%s) error = %v, want ErrInvalidBanExpiry", in, err)
+		}
+	}
+}
+
+func TestBanExpiryRoundTrip(t *testing.T) {
+	for _, e := range []gomcsmp.BanExpiry{
+		gomcsmp.Permanent(),
+		gomcsmp.ExpiresAt(time.Date(2030, 5, 1, 12, 30, 15, 999, time.Local)),
+	} {
+		b, err := json.Marshal(e)
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+
+		var got gomcsmp.BanExpiry
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", b, err)
+		}
+
+		want, _ := e.Time()
+		if at, _ := got.Time(); !at.Equal(want) || got.IsPermanent() != e.IsPermanent() {
+			t.Fatalf("round trip of %s = %v, want %v", b, got, e)
+		}
+	}
+}
+
+func TestBanExpiryExpired(t *testing.T) {
+	if gomcsmp.Permanent().Expired() {
+		t.Fatal("permanent expiry is expired")
+	}
+	if gomcsmp.Permanent().Remaining() != 0 {
+		t.Fatal("permanent expiry has time remaining")
+	}
+	if !gomcsmp.ExpiresAfter(-time.Hour).Expired() {
+		t.Fatal("expiry an hour ago is not expired")
+	}
+	if e := gomcsmp.ExpiresAfter(time.Hour); e.Expired() || e.Remaining() <= 0 {
+		t.Fatalf("expiry in an hour: expired %v, remaining %v", e.Expired(), e.Remaining())
+	}
+}
@@ -0,0 +1,108 @@
+package gomcsmp
+
+import (
+	"crypto/md5"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidPlayerName = errors.New("invalid player name")
+	ErrInvalidPlayerUUID = errors.New("invalid player UUID")
+)
+
+const (
+	playerNameMinLen = 3
+	playerNameMaxLen = 16
+)
+
+// ValidatePlayerName - checks a Java Edition username:
+// 3 to 16 characters of letters, digits and underscores.
+func ValidatePlayerName(name string) error {
+	if len(name) < playerNameMinLen || len(name) > playerNameMaxLen {
+		return fmt.Errorf(
+			"%w: '%s' must be %d to %d characters long",
+			ErrInvalidPlayerName, name, playerNameMinLen, playerNameMaxLen,
+		)
+	}
+
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+		default:
+			return fmt.Errorf("%w: '%s' contains '%c'", ErrInvalidPlayerName, name, r)
+		}
+	}
+
+	return nil
+}
+
+// ValidatePlayerUUID - checks that the UUID is a player UUID: version 4
+// for online-mode accounts or version 3 for offline-mode players.
+func ValidatePlayerUUID(id uuid.UUID) error {
+	if id.Variant() != uuid.RFC4122 {
+		return fmt.Errorf("%w: %s has variant %s", ErrInvalidPlayerUUID, id, id.Variant())
+	}
+	if v := id.Version(); v != 3 && v != 4 {
+		return fmt.Errorf("%w: %s has version %d", ErrInvalidPlayerUUID, id, v)
+	}
+	return nil
+}
+
+// IsOfflineUUID - reports whether the UUID was derived for an offline-mode player.
+func IsOfflineUUID(id uuid.UUID) bool {
+	return id.Variant() == uuid.RFC4122 && id.Version() == 3
+}
+
+// OfflineUUID - computes the UUID an offline-mode server assigns to the name,
+// a version 3 UUID of "OfflinePlayer:<name>".
+func OfflineUUID(name string) uuid.UUID {
+	id := uuid.UUID(md5.Sum([]byte("OfflinePlayer:" + name)))
+	id[6] = (id[6] & 0x0f) | 0x30
+	id[8] = (id[8] & 0x3f) | 0x80
+	return id
+}
+
+// NewOfflinePlayer - creates a Player with the offline-mode UUID of the name.
+func NewOfflinePlayer(name string) Player {
+	id := OfflineUUID(name)
+	return Player{
+		Name: name,
+		ID:   &id,
+	}
+}
+
+// Validate - checks the player name and UUID when present.
+// A player must have at least one of them.
+// Only new entries are validated by the client, on the add paths;
+// remove and set accept entries as the server reports them.
+func (player Player) Validate() error {
+	if player.Name == "" && player.ID == nil {
+		return fmt.Errorf("%w: player has neither name nor UUID", ErrInvalidPlayerName)
+	}
+
+	if player.Name != "" {
+		if err := ValidatePlayerName(player.Name); err != nil {
+			return err
+		}
+	}
+
+	if player.ID != nil {
+		if err := ValidatePlayerUUID(*player.ID); err != nil {
+			return fmt.Errorf("player '%s': %w", player.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func validatePlayers(players ...Player) error {
+	for _, p := range players {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
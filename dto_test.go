@@ -0,0 +1,148 @@
+package gomcsmp_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+)
+
+var (
+	idSteve = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	idAlex  = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+)
+
+func playerWithID(name string, id uuid.UUID) gomcsmp.Player {
+	p := gomcsmp.NewPlayer(name)
+	p.ID = &id
+	return p
+}
+
+func registry(players ...gomcsmp.Player) *gomcsmp.PlayerRegistry {
+	return gomcsmp.NewPlayerRegistry(players)
+}
+
+// entries - returns the players of r as "name/N" with the last UUID digit, "name/-" without a UUID.
+func entries(r *gomcsmp.PlayerRegistry) []string {
+	out := []string{}
+	for p := range r.All() {
+		id := "-"
+		if p.ID != nil {
+			s := p.ID.String()
+			id = s[len(s)-1:]
+		}
+		out = append(out, p.Name+"/"+id)
+	}
+	return out
+}
+
+func TestPlayerRegistrySetOperations(t *testing.T) {
+	steve, alex := gomcsmp.NewPlayer("Steve"), gomcsmp.NewPlayer("Alex")
+	steveID, alexID := playerWithID("Steve", idSteve), playerWithID("Alex", idAlex)
+	stevieID := playerWithID("Stevie", idSteve) // Steve after a rename
+	otherSteve := playerWithID("Steve", idAlex) // the name taken by another account
+
+	tests := []struct {
+		name      string
+		a, b      *gomcsmp.PlayerRegistry
+		union     []string
+		diff      []string
+		intersect []string
+		equal     bool
+	}{
+		{
+			name: "disjoint names",
+			a:    registry(steve), b: registry(alex),
+			union: []string{"Alex/-", "Steve/-"}, diff: []string{"Steve/-"}, intersect: []string{},
+		},
+		{
+			name: "names ignore case",
+			a:    registry(steve), b: registry(gomcsmp.NewPlayer("sTEVE")),
+			union: []string{"sTEVE/-"}, diff: []string{}, intersect: []string{"Steve/-"}, equal: true,
+		},
+		{
+			name: "uuid matches",
+			a:    registry(steveID, alexID), b: registry(alexID),
+			union: []string{"Alex/2", "Steve/1"}, diff: []string{"Steve/1"}, intersect: []string{"Alex/2"},
+		},
+		{
+			name: "entry without uuid matches by name",
+			a:    registry(steve), b: registry(steveID),
+			union: []string{"Steve/1"}, diff: []string{}, intersect: []string{"Steve/1"}, equal: true,
+		},
+		{
+			name: "uuid entry matches entry without uuid",
+			a:    registry(steveID), b: registry(gomcsmp.NewPlayer("STEVE")),
+			union: []string{"STEVE/1"}, diff: []string{}, intersect: []string{"Steve/1"}, equal: true,
+		},
+		{
+			name: "renamed player with the same uuid",
+			a:    registry(steveID), b: registry(stevieID),
+			union: []string{"Stevie/1"}, diff: []string{}, intersect: []string{"Steve/1"}, equal: true,
+		},
+		{
+			name: "same name with another uuid",
+			a:    registry(steveID), b: registry(otherSteve),
+			union: []string{"Steve/1", "Steve/2"}, diff: []string{"Steve/1"}, intersect: []string{},
+		},
+		{
+			name: "empty",
+			a:    registry(), b: registry(steve),
+			union: []string{"Steve/-"}, diff: []string{}, intersect: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := entries(tt.a.Union(tt.b)); !reflect.DeepEqual(got, tt.union) {
+				t.Errorf("Union = %v, want %v", got, tt.union)
+			}
+			if got := entries(tt.a.Difference(tt.b)); !reflect.DeepEqual(got, tt.diff) {
+				t.Errorf("Difference = %v, want %v", got, tt.diff)
+			}
+			if got := entries(tt.a.Intersect(tt.b)); !reflect.DeepEqual(got, tt.intersect) {
+				t.Errorf("Intersect = %v, want %v", got, tt.intersect)
+			}
+			if got := tt.a.Equal(tt.b); got != tt.equal {
+				t.Errorf("Equal = %v, want %v", got, tt.equal)
+			}
+			if got := tt.b.Equal(tt.a); got != tt.equal {
+				t.Errorf("reversed Equal = %v, want %v", got, tt.equal)
+			}
+		})
+	}
+}
+
+func TestPlayerRegistryRename(t *testing.T) {
+	r := registry(playerWithID("Steve", idSteve))
+	r.Add(playerWithID("Stevie", idSteve))
+
+	if got, want := entries(r), []string{"Stevie/1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("entries = %v, want %v", got, want)
+	}
+	if r.Contains("Steve") {
+		t.Fatal("old name is still present")
+	}
+	if id, ok := r.UUIDByName("STEVIE"); !ok || id != idSteve {
+		t.Fatalf("UUIDByName(STEVIE) = %v, %v", id, ok)
+	}
+}
+
+func TestPlayerRegistryJSON(t *testing.T) {
+	r := registry(playerWithID("Steve", idSteve), gomcsmp.NewPlayer("Alex"))
+
+	b, err := r.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	var got gomcsmp.PlayerRegistry
+	if err := got.UnmarshalJSON(b); err != nil {
+		t.Fatalf("UnmarshalJSON(%s): %v", b, err)
+	}
+	if !got.Equal(r) || !reflect.DeepEqual(entries(&got), entries(r)) {
+		t.Fatalf("round trip = %v, want %v", entries(&got), entries(r))
+	}
+}
@@ -0,0 +1,303 @@
+package gomcsmp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// GameRulePreset - a named set of game rule values, e.g. "build week".
+// In JSON rule values are written as native booleans and numbers:
+//
+//	{"name": "build week", "rules": {"doDaylightCycle": false, "randomTickSpeed": 0}}
+type GameRulePreset struct {
+	Name  string
+	Rules map[GameRuleKey]string
+}
+
+// NewGameRulePreset - creates a preset from rule values.
+func NewGameRulePreset(name string, rules ...GameRule) GameRulePreset {
+	p := GameRulePreset{
+		Name:  name,
+		Rules: make(map[GameRuleKey]string, len(rules)),
+	}
+	for _, r := range rules {
+		p.Rules[GameRuleKey(r.Key)] = r.Value
+	}
+	return p
+}
+
+// Validate - checks every rule of the preset against the game rule catalogue.
+// Keys missing from it fail with ErrUnknownGameRule, see ValidateLive for datapack rules.
+func (p GameRulePreset) Validate() error {
+	return p.validate(ValidateGameRule)
+}
+
+// ValidateLive - checks the rules of the preset like Validate, but also accepts
+// keys missing from the catalogue which the server reports in live.
+func (p GameRulePreset) ValidateLive(live GameRules) error {
+	return p.validate(func(rule GameRule) error {
+		return ValidateLiveGameRule(rule, live)
+	})
+}
+
+func (p GameRulePreset) validate(check func(GameRule) error) error {
+	var errs []error
+	for _, key := range p.keys() {
+		if err := check(NewGameRule(p.Rules[key], string(key), UntypedGameRule)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p GameRulePreset) keys() []GameRuleKey {
+	keys := make([]GameRuleKey, 0, len(p.Rules))
+	for k := range p.Rules {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+type gameRulePresetJSON struct {
+	Name  string                          `json:"name"`
+	Rules map[GameRuleKey]json.RawMessage `json:"rules"`
+}
+
+func (p GameRulePreset) MarshalJSON() ([]byte, error) {
+	out := gameRulePresetJSON{
+		Name:  p.Name,
+		Rules: make(map[GameRuleKey]json.RawMessage, len(p.Rules)),
+	}
+
+	for key, value := range p.Rules {
+		if value == "true" || value == "false" {
+			out.Rules[key] = json.RawMessage(value)
+			continue
+		}
+		if n, err := strconv.Atoi(value); err == nil {
+			out.Rules[key] = json.RawMessage(strconv.Itoa(n))
+			continue
+		}
+
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		out.Rules[key] = raw
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON - accepts rule values as booleans, numbers or strings.
+func (p *GameRulePreset) UnmarshalJSON(b []byte) error {
+	var in gameRulePresetJSON
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+
+	p.Name = in.Name
+	p.Rules = make(map[GameRuleKey]string, len(in.Rules))
+
+	for key, raw := range in.Rules {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			p.Rules[key] = s
+			continue
+		}
+
+		var v any
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return err
+		}
+		switch v.(type) {
+		case bool, float64:
+			p.Rules[key] = string(raw)
+		default:
+			return fmt.Errorf("%w: '%s' has value %s", ErrInvalidGameRule, key, raw)
+		}
+	}
+
+	return nil
+}
+
+// ReadGameRulePreset - reads a preset in JSON.
+func ReadGameRulePreset(rd io.Reader) (GameRulePreset, error) {
+	var p GameRulePreset
+	if err := json.NewDecoder(rd).Decode(&p); err != nil {
+		return GameRulePreset{}, fmt.Errorf("read gamerule preset: %w", err)
+	}
+	return p, nil
+}
+
+// LoadGameRulePreset - reads a preset from a JSON file.
+func LoadGameRulePreset(path string) (GameRulePreset, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return GameRulePreset{}, fmt.Errorf("open gamerule preset: %w", err)
+	}
+	defer f.Close()
+
+	return ReadGameRulePreset(f)
+}
+
+// Write - writes the preset as indented JSON.
+func (p GameRulePreset) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(p); err != nil {
+		return fmt.Errorf("write gamerule preset: %w", err)
+	}
+	return nil
+}
+
+// Save - writes the preset to a JSON file, replacing it.
+func (p GameRulePreset) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create gamerule preset: %w", err)
+	}
+
+	if err := p.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ============
+
+// GameRuleChange - a rule whose current value differs from the preset.
+// From is empty when the server does not report the rule.
+type GameRuleChange struct {
+	Key  GameRuleKey
+	From string
+	To   string
+}
+
+// Diff - returns the rules of the preset which differ from current, ordered by key.
+func (p GameRulePreset) Diff(current GameRules) []GameRuleChange {
+	values := current.Map()
+
+	changes := []GameRuleChange{}
+	for _, key := range p.keys() {
+		to := p.Rules[key]
+		if from, ok := values[key]; !ok || !strings.EqualFold(from, to) {
+			changes = append(changes, GameRuleChange{Key: key, From: values[key], To: to})
+		}
+	}
+	return changes
+}
+
+// ============
+
+// GameRuleStatus - the outcome of one rule of a preset apply.
+type GameRuleStatus string
+
+const (
+	GameRuleApplied        GameRuleStatus = "applied"
+	GameRuleFailed         GameRuleStatus = "failed"
+	GameRuleSkipped        GameRuleStatus = "skipped"
+	GameRuleRolledBack     GameRuleStatus = "rolled_back"
+	GameRuleRollbackFailed GameRuleStatus = "rollback_failed"
+)
+
+// GameRuleResult - the outcome of one changed rule.
+type GameRuleResult struct {
+	GameRuleChange
+	Status GameRuleStatus
+	Err    error
+}
+
+// GameRulePresetReport - the outcome of applying a preset.
+// Results hold one entry per changed rule in the order they were applied,
+// RolledBack reports whether a failed apply restored every rule it had changed.
+type GameRulePresetReport struct {
+	Preset     string
+	Unchanged  int
+	Results    []GameRuleResult
+	RolledBack bool
+}
+
+// Apply - updates the rules which differ from the server state, one by one.
+// The preset is checked with ValidateLive first, so nothing is sent when a key
+// is neither in the catalogue nor reported by the server. An RPCClient sends keys
+// missing from the catalogue only when allowed with WithCustomGameRules.
+// When an update fails, the rules already applied are restored to their previous
+// values in reverse order and the remaining rules are skipped.
+// The report is returned even when the apply fails.
+func (p GameRulePreset) Apply(ctx context.Context, api GamerulesAPI) (*GameRulePresetReport, error) {
+	current, err := api.GamerulesGet(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gamerules: %w", err)
+	}
+
+	if err := p.ValidateLive(current); err != nil {
+		return nil, fmt.Errorf("invalid preset '%s': %w", p.Name, err)
+	}
+
+	changes := p.Diff(current)
+	report := &GameRulePresetReport{
+		Preset:    p.Name,
+		Unchanged: len(p.Rules) - len(changes),
+		Results:   make([]GameRuleResult, len(changes)),
+	}
+
+	for i, ch := range changes {
+		report.Results[i] = GameRuleResult{GameRuleChange: ch, Status: GameRuleSkipped}
+	}
+
+	for i, ch := range changes {
+		_, err := api.GamerulesUpdate(ctx, NewGameRule(ch.To, string(ch.Key), UntypedGameRule))
+		if err == nil {
+			report.Results[i].Status = GameRuleApplied
+			continue
+		}
+
+		report.Results[i].Status = GameRuleFailed
+		report.Results[i].Err = err
+
+		rollbackErr := report.rollback(context.WithoutCancel(ctx), api, i)
+		return report, errors.Join(
+			fmt.Errorf("failed to update gamerule '%s': %w", ch.Key, err),
+			rollbackErr,
+		)
+	}
+
+	return report, nil
+}
+
+// rollback - restores the rules applied before the failed one.
+func (report *GameRulePresetReport) rollback(ctx context.Context, api GamerulesAPI, failed int) error {
+	var errs []error
+
+	for i := failed - 1; i >= 0; i-- {
+		res := &report.Results[i]
+		if res.From == "" {
+			res.Status = GameRuleRollbackFailed
+			res.Err = fmt.Errorf("previous value of '%s' is unknown", res.Key)
+			errs = append(errs, res.Err)
+			continue
+		}
+
+		_, err := api.GamerulesUpdate(ctx, NewGameRule(res.From, string(res.Key), UntypedGameRule))
+		if err != nil {
+			res.Status = GameRuleRollbackFailed
+			res.Err = err
+			errs = append(errs, fmt.Errorf("failed to roll back gamerule '%s': %w", res.Key, err))
+			continue
+		}
+		res.Status = GameRuleRolledBack
+	}
+
+	report.RolledBack = len(errs) == 0
+	return errors.Join(errs...)
+}
@@ -0,0 +1,122 @@
+package gomcsmp_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+)
+
+// stubGamerules - a GamerulesAPI over a fixed rule list which records updates.
+type stubGamerules struct {
+	rules   gomcsmp.GameRules
+	fail    map[gomcsmp.GameRuleKey]bool
+	updates []string
+}
+
+func (s *stubGamerules) GamerulesGet(ctx context.Context) (gomcsmp.GameRules, error) {
+	return s.rules, nil
+}
+
+func (s *stubGamerules) GamerulesUpdate(ctx context.Context, rule gomcsmp.GameRule) (*gomcsmp.GameRule, error) {
+	s.updates = append(s.updates, rule.Key+"="+rule.Value)
+	if s.fail[gomcsmp.GameRuleKey(rule.Key)] {
+		return nil, errors.New("update refused")
+	}
+	return &rule, nil
+}
+
+func TestGameRulePresetApply(t *testing.T) {
+	api := &stubGamerules{rules: gomcsmp.GameRules{
+		gomcsmp.GameRuleKeepInventory.Bool(false),
+		gomcsmp.GameRuleDoDaylightCycle.Bool(false),
+	}}
+
+	preset := gomcsmp.NewGameRulePreset("build",
+		gomcsmp.GameRuleKeepInventory.Bool(true),
+		gomcsmp.GameRuleDoDaylightCycle.Bool(false),
+	)
+
+	report, err := preset.Apply(context.Background(), api)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if report.Unchanged != 1 || len(report.Results) != 1 || report.Results[0].Status != gomcsmp.GameRuleApplied {
+		t.Fatalf("report = %+v, want keepInventory applied and one rule unchanged", report)
+	}
+	if want := []string{"keepInventory=true"}; !reflect.DeepEqual(api.updates, want) {
+		t.Fatalf("updates = %v, want %v", api.updates, want)
+	}
+}
+
+func TestGameRulePresetRollback(t *testing.T) {
+	// keepInventory is not reported by the server, so its previous value is unknown
+	api := &stubGamerules{
+		rules: gomcsmp.GameRules{
+			gomcsmp.GameRuleDoDaylightCycle.Bool(true),
+			gomcsmp.GameRuleRandomTickSpeed.Int(3),
+		},
+		fail: map[gomcsmp.GameRuleKey]bool{gomcsmp.GameRuleRandomTickSpeed: true},
+	}
+
+	preset := gomcsmp.NewGameRulePreset("build",
+		gomcsmp.GameRuleDoDaylightCycle.Bool(false),
+		gomcsmp.GameRuleKeepInventory.Bool(true),
+		gomcsmp.GameRuleRandomTickSpeed.Int(0),
+	)
+
+	report, err := preset.Apply(context.Background(), api)
+	if err == nil {
+		t.Fatal("Apply succeeded, want the update error")
+	}
+	if !strings.Contains(err.Error(), "previous value of 'keepInventory' is unknown") {
+		t.Fatalf("error = %v, want the unknown previous value reported", err)
+	}
+	if report == nil || report.RolledBack {
+		t.Fatalf("report = %+v, want a report which is not fully rolled back", report)
+	}
+
+	want := map[gomcsmp.GameRuleKey]gomcsmp.GameRuleStatus{
+		gomcsmp.GameRuleDoDaylightCycle: gomcsmp.GameRuleRolledBack,
+		gomcsmp.GameRuleKeepInventory:   gomcsmp.GameRuleRollbackFailed,
+		gomcsmp.GameRuleRandomTickSpeed: gomcsmp.GameRuleFailed,
+	}
+	for _, res := range report.Results {
+		if res.Status != want[res.Key] {
+			t.Errorf("%s status = %s, want %s", res.Key, res.Status, want[res.Key])
+		}
+	}
+
+	wantUpdates := []string{"doDaylightCycle=false", "keepInventory=true", "randomTickSpeed=0", "doDaylightCycle=true"}
+	if !reflect.DeepEqual(api.updates, wantUpdates) {
+		t.Fatalf("updates = %v, want %v", api.updates, wantUpdates)
+	}
+}
+
+func TestGameRulePresetJSON(t *testing.T) {
+	in := `{"name":"build week","rules":{"doDaylightCycle":false,"randomTickSpeed":0,"mypack:mode":"fast"}}`
+
+	p, err := gomcsmp.ReadGameRulePreset(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("ReadGameRulePreset: %v", err)
+	}
+	want := map[gomcsmp.GameRuleKey]string{"doDaylightCycle": "false", "randomTickSpeed": "0", "mypack:mode": "fast"}
+	if p.Name != "build week" || !reflect.DeepEqual(p.Rules, want) {
+		t.Fatalf("preset = %+v, want %v", p, want)
+	}
+
+	var buf strings.Builder
+	if err := p.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	again, err := gomcsmp.ReadGameRulePreset(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("ReadGameRulePreset(%s): %v", buf.String(), err)
+	}
+	if !reflect.DeepEqual(again, p) {
+		t.Fatalf("round trip = %+v, want %+v", again, p)
+	}
+}
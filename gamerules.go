@@ -0,0 +1,349 @@
+package gomcsmp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+var (
+	ErrUnknownGameRule = errors.New("unknown game rule")
+	ErrInvalidGameRule = errors.New("invalid game rule value")
+)
+
+// GameRuleKey - the key of a vanilla game rule.
+type GameRuleKey string
+
+const (
+	GameRuleAllowEnteringNetherUsingPortals  GameRuleKey = "allowEnteringNetherUsingPortals"
+	GameRuleAllowFireTicksAwayFromPlayer     GameRuleKey = "allowFireTicksAwayFromPlayer"
+	GameRuleAnnounceAdvancements             GameRuleKey = "announceAdvancements"
+	GameRuleBlockExplosionDropDecay          GameRuleKey = "blockExplosionDropDecay"
+	GameRuleCommandBlockOutput               GameRuleKey = "commandBlockOutput"
+	GameRuleCommandBlocksEnabled             GameRuleKey = "commandBlocksEnabled"
+	GameRuleCommandModificationBlockLimit    GameRuleKey = "commandModificationBlockLimit"
+	GameRuleDisableElytraMovementCheck       GameRuleKey = "disableElytraMovementCheck"
+	GameRuleDisablePlayerMovementCheck       GameRuleKey = "disablePlayerMovementCheck"
+	GameRuleDisableRaids                     GameRuleKey = "disableRaids"
+	GameRuleDoDaylightCycle                  GameRuleKey = "doDaylightCycle"
+	GameRuleDoEntityDrops                    GameRuleKey = "doEntityDrops"
+	GameRuleDoFireTick                       GameRuleKey = "doFireTick"
+	GameRuleDoImmediateRespawn               GameRuleKey = "doImmediateRespawn"
+	GameRuleDoInsomnia                       GameRuleKey = "doInsomnia"
+	GameRuleDoLimitedCrafting                GameRuleKey = "doLimitedCrafting"
+	GameRuleDoMobLoot                        GameRuleKey = "doMobLoot"
+	GameRuleDoMobSpawning                    GameRuleKey = "doMobSpawning"
+	GameRuleDoPatrolSpawning                 GameRuleKey = "doPatrolSpawning"
+	GameRuleDoTileDrops                      GameRuleKey = "doTileDrops"
+	GameRuleDoTraderSpawning                 GameRuleKey = "doTraderSpawning"
+	GameRuleDoVinesSpread                    GameRuleKey = "doVinesSpread"
+	GameRuleDoWardenSpawning                 GameRuleKey = "doWardenSpawning"
+	GameRuleDoWeatherCycle                   GameRuleKey = "doWeatherCycle"
+	GameRuleDrowningDamage                   GameRuleKey = "drowningDamage"
+	GameRuleEnderPearlsVanishOnDeath         GameRuleKey = "enderPearlsVanishOnDeath"
+	GameRuleFallDamage                       GameRuleKey = "fallDamage"
+	GameRuleFireDamage                       GameRuleKey = "fireDamage"
+	GameRuleForgiveDeadPlayers               GameRuleKey = "forgiveDeadPlayers"
+	GameRuleFreezeDamage                     GameRuleKey = "freezeDamage"
+	GameRuleGlobalSoundEvents                GameRuleKey = "globalSoundEvents"
+	GameRuleKeepInventory                    GameRuleKey = "keepInventory"
+	GameRuleLavaSourceConversion             GameRuleKey = "lavaSourceConversion"
+	GameRuleLocatorBar                       GameRuleKey = "locatorBar"
+	GameRuleLogAdminCommands                 GameRuleKey = "logAdminCommands"
+	GameRuleMaxCommandChainLength            GameRuleKey = "maxCommandChainLength"
+	GameRuleMaxCommandForkCount              GameRuleKey = "maxCommandForkCount"
+	GameRuleMaxEntityCramming                GameRuleKey = "maxEntityCramming"
+	GameRuleMobExplosionDropDecay            GameRuleKey = "mobExplosionDropDecay"
+	GameRuleMobGriefing                      GameRuleKey = "mobGriefing"
+	GameRuleNaturalRegeneration              GameRuleKey = "naturalRegeneration"
+	GameRulePlayersNetherPortalCreativeDelay GameRuleKey = "playersNetherPortalCreativeDelay"
+	GameRulePlayersNetherPortalDefaultDelay  GameRuleKey = "playersNetherPortalDefaultDelay"
+	GameRulePlayersSleepingPercentage        GameRuleKey = "playersSleepingPercentage"
+	GameRuleProjectilesCanBreakBlocks        GameRuleKey = "projectilesCanBreakBlocks"
+	GameRulePvp                              GameRuleKey = "pvp"
+	GameRuleRandomTickSpeed                  GameRuleKey = "randomTickSpeed"
+	GameRuleReducedDebugInfo                 GameRuleKey = "reducedDebugInfo"
+	GameRuleSendCommandFeedback              GameRuleKey = "sendCommandFeedback"
+	GameRuleShowDeathMessages                GameRuleKey = "showDeathMessages"
+	GameRuleSnowAccumulationHeight           GameRuleKey = "snowAccumulationHeight"
+	GameRuleSpawnChunkRadius                 GameRuleKey = "spawnChunkRadius"
+	GameRuleSpawnRadius                      GameRuleKey = "spawnRadius"
+	GameRuleSpawnerBlocksEnabled             GameRuleKey = "spawnerBlocksEnabled"
+	GameRuleSpectatorsGenerateChunks         GameRuleKey = "spectatorsGenerateChunks"
+	GameRuleTntExplodes                      GameRuleKey = "tntExplodes"
+	GameRuleTntExplosionDropDecay            GameRuleKey = "tntExplosionDropDecay"
+	GameRuleUniversalAnger                   GameRuleKey = "universalAnger"
+	GameRuleWaterSourceConversion            GameRuleKey = "waterSourceConversion"
+)
+
+// Bool - returns a boolean rule update for the key.
+func (k GameRuleKey) Bool(v bool) GameRule {
+	return NewGameRuleBoolean(v, string(k))
+}
+
+// Int - returns an integer rule update for the key.
+func (k GameRuleKey) Int(v int) GameRule {
+	return NewGameRuleInteger(v, string(k))
+}
+
+// ============
+
+// GameRuleInfo - describes a vanilla game rule.
+// Min and Max bound the values of integer rules.
+// Introduced is the first version with the rule, Removed the first version without it.
+type GameRuleInfo struct {
+	Key        GameRuleKey
+	Type       GameRuleType
+	Default    string
+	Min        int
+	Max        int
+	Introduced string
+	Removed    string
+}
+
+func boolRule(key GameRuleKey, def bool, introduced string) GameRuleInfo {
+	return GameRuleInfo{
+		Key:        key,
+		Type:       BooleanGameRule,
+		Default:    strconv.FormatBool(def),
+		Introduced: introduced,
+	}
+}
+
+func intRule(key GameRuleKey, def, min, max int, introduced string) GameRuleInfo {
+	return GameRuleInfo{
+		Key:        key,
+		Type:       IntegerGameRule,
+		Default:    strconv.Itoa(def),
+		Min:        min,
+		Max:        max,
+		Introduced: introduced,
+	}
+}
+
+func removedIn(info GameRuleInfo, version string) GameRuleInfo {
+	info.Removed = version
+	return info
+}
+
+const maxRuleInt = math.MaxInt32
+
+var gameRuleCatalogue = []GameRuleInfo{
+	boolRule(GameRuleAllowEnteringNetherUsingPortals, true, "1.21.9"),
+	boolRule(GameRuleAllowFireTicksAwayFromPlayer, false, "1.21.5"),
+	boolRule(GameRuleAnnounceAdvancements, true, "1.12"),
+	boolRule(GameRuleBlockExplosionDropDecay, true, "1.19.3"),
+	boolRule(GameRuleCommandBlockOutput, true, "1.4.2"),
+	boolRule(GameRuleCommandBlocksEnabled, true, "1.21.9"),
+	intRule(GameRuleCommandModificationBlockLimit, 32768, 1, maxRuleInt, "1.19.4"),
+	boolRule(GameRuleDisableElytraMovementCheck, false, "1.9"),
+	boolRule(GameRuleDisablePlayerMovementCheck, false, "1.21.2"),
+	boolRule(GameRuleDisableRaids, false, "1.14.3"),
+	boolRule(GameRuleDoDaylightCycle, true, "1.6.1"),
+	boolRule(GameRuleDoEntityDrops, true, "1.8.1"),
+	boolRule(GameRuleDoFireTick, true, "1.4.2"),
+	boolRule(GameRuleDoImmediateRespawn, false, "1.15"),
+	boolRule(GameRuleDoInsomnia, true, "1.15"),
+	boolRule(GameRuleDoLimitedCrafting, false, "1.12"),
+	boolRule(GameRuleDoMobLoot, true, "1.4.2"),
+	boolRule(GameRuleDoMobSpawning, true, "1.4.2"),
+	boolRule(GameRuleDoPatrolSpawning, true, "1.15.2"),
+	boolRule(GameRuleDoTileDrops, true, "1.4.2"),
+	boolRule(GameRuleDoTraderSpawning, true, "1.15.2"),
+	boolRule(GameRuleDoVinesSpread, true, "1.19.4"),
+	boolRule(GameRuleDoWardenSpawning, true, "1.19"),
+	boolRule(GameRuleDoWeatherCycle, true, "1.11"),
+	boolRule(GameRuleDrowningDamage, true, "1.15"),
+	boolRule(GameRuleEnderPearlsVanishOnDeath, true, "1.20.2"),
+	boolRule(GameRuleFallDamage, true, "1.15"),
+	boolRule(GameRuleFireDamage, true, "1.15"),
+	boolRule(GameRuleForgiveDeadPlayers, true, "1.16"),
+	boolRule(GameRuleFreezeDamage, true, "1.17"),
+	boolRule(GameRuleGlobalSoundEvents, true, "1.19.3"),
+	boolRule(GameRuleKeepInventory, false, "1.4.2"),
+	boolRule(GameRuleLavaSourceConversion, false, "1.19.3"),
+	boolRule(GameRuleLocatorBar, true, "1.21.6"),
+	boolRule(GameRuleLogAdminCommands, true, "1.8"),
+	intRule(GameRuleMaxCommandChainLength, 65536, 0, maxRuleInt, "1.12"),
+	intRule(GameRuleMaxCommandForkCount, 65536, 0, maxRuleInt, "1.20.3"),
+	intRule(GameRuleMaxEntityCramming, 24, 0, maxRuleInt, "1.11"),
+	boolRule(GameRuleMobExplosionDropDecay, true, "1.19.3"),
+	boolRule(GameRuleMobGriefing, true, "1.4.2"),
+	boolRule(GameRuleNaturalRegeneration, true, "1.6.1"),
+	intRule(GameRulePlayersNetherPortalCreativeDelay, 1, 0, maxRuleInt, "1.20.3"),
+	intRule(GameRulePlayersNetherPortalDefaultDelay, 80, 0, maxRuleInt, "1.20.3"),
+	intRule(GameRulePlayersSleepingPercentage, 100, 0, maxRuleInt, "1.17"),
+	boolRule(GameRuleProjectilesCanBreakBlocks, true, "1.20.3"),
+	boolRule(GameRulePvp, true, "1.21.9"),
+	intRule(GameRuleRandomTickSpeed, 3, 0, maxRuleInt, "1.8"),
+	boolRule(GameRuleReducedDebugInfo, false, "1.8"),
+	boolRule(GameRuleSendCommandFeedback, true, "1.8"),
+	boolRule(GameRuleShowDeathMessages, true, "1.8"),
+	intRule(GameRuleSnowAccumulationHeight, 1, 0, 8, "1.19.3"),
+	removedIn(intRule(GameRuleSpawnChunkRadius, 2, 0, 32, "1.20.5"), "1.21.9"),
+	intRule(GameRuleSpawnRadius, 10, 0, maxRuleInt, "1.9"),
+	boolRule(GameRuleSpawnerBlocksEnabled, true, "1.21.9"),
+	boolRule(GameRuleSpectatorsGenerateChunks, true, "1.8"),
+	boolRule(GameRuleTntExplodes, true, "1.21.5"),
+	boolRule(GameRuleTntExplosionDropDecay, false, "1.19.3"),
+	boolRule(GameRuleUniversalAnger, false, "1.16"),
+	boolRule(GameRuleWaterSourceConversion, true, "1.19.3"),
+}
+
+// GameRuleCatalogue - returns all known vanilla game rules ordered by key.
+func GameRuleCatalogue() []GameRuleInfo {
+	out := make([]GameRuleInfo, len(gameRuleCatalogue))
+	copy(out, gameRuleCatalogue)
+	return out
+}
+
+// LookupGameRule - returns the description of the game rule with the given key.
+func LookupGameRule(key GameRuleKey) (GameRuleInfo, bool) {
+	for _, info := range gameRuleCatalogue {
+		if info.Key == key {
+			return info, true
+		}
+	}
+	return GameRuleInfo{}, false
+}
+
+// DefaultRule - returns the rule set to its default value.
+func (info GameRuleInfo) DefaultRule() GameRule {
+	return NewGameRule(info.Default, string(info.Key), info.Type)
+}
+
+// SupportedBy - reports whether a server of the given version has the rule.
+// Unparsable versions are treated as supported.
+func (info GameRuleInfo) SupportedBy(v Version) bool {
+	if _, ok := versionParts(v.Name); !ok {
+		return true
+	}
+	if !versionAtLeast(v, info.Introduced) {
+		return false
+	}
+	return info.Removed == "" || !versionAtLeast(v, info.Removed)
+}
+
+// Validate - checks that the value fits the type and range of the rule.
+func (info GameRuleInfo) Validate(value string) error {
+	switch info.Type {
+	case BooleanGameRule:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("%w: '%s' expects boolean, got '%s'", ErrInvalidGameRule, info.Key, value)
+		}
+	case IntegerGameRule:
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%w: '%s' expects integer, got '%s'", ErrInvalidGameRule, info.Key, value)
+		}
+		if v < info.Min || v > info.Max {
+			return fmt.Errorf("%w: '%s' must be in [%d, %d], got %d", ErrInvalidGameRule, info.Key, info.Min, info.Max, v)
+		}
+	}
+	return nil
+}
+
+// ValidateGameRule - type-checks a rule update against the catalogue.
+// Rules the catalogue knows must have a matching type, when set, and a fitting value.
+// Keys missing from the catalogue fail with ErrUnknownGameRule, so a typo such as
+// "keepInventroy" never reaches the server. Datapack or modded rules, rules of newer
+// servers and snake_case keys are checked with ValidateLiveGameRule instead,
+// or let through by the client with WithCustomGameRules.
+func ValidateGameRule(rule GameRule) error {
+	info, ok := LookupGameRule(GameRuleKey(rule.Key))
+	if !ok {
+		return fmt.Errorf("%w: '%s'", ErrUnknownGameRule, rule.Key)
+	}
+	if rule.Type != UntypedGameRule && rule.Type != info.Type {
+		return fmt.Errorf("%w: '%s' is %s, got %s", ErrInvalidGameRule, rule.Key, info.Type, rule.Type)
+	}
+	return info.Validate(rule.Value)
+}
+
+// ValidateLiveGameRule - like ValidateGameRule, but also accepts keys missing from
+// the catalogue which the server reports in live, checking the value against their type.
+func ValidateLiveGameRule(rule GameRule, live GameRules) error {
+	if _, ok := LookupGameRule(GameRuleKey(rule.Key)); ok {
+		return ValidateGameRule(rule)
+	}
+
+	liveRule, ok := live.Get(GameRuleKey(rule.Key))
+	if !ok {
+		return fmt.Errorf("%w: '%s' is not reported by the server", ErrUnknownGameRule, rule.Key)
+	}
+	return validateCustomGameRule(rule, liveRule.Type)
+}
+
+// validateCustomGameRule - checks a rule missing from the catalogue against the type
+// known for it, if any, and the type it declares.
+func validateCustomGameRule(rule GameRule, known GameRuleType) error {
+	typ := rule.Type
+	switch {
+	case known == UntypedGameRule:
+	case typ == UntypedGameRule:
+		typ = known
+	case typ != known:
+		return fmt.Errorf("%w: '%s' is %s, got %s", ErrInvalidGameRule, rule.Key, known, typ)
+	}
+
+	if typ == UntypedGameRule {
+		return nil
+	}
+	return GameRuleInfo{Key: GameRuleKey(rule.Key), Type: typ, Min: math.MinInt, Max: math.MaxInt}.Validate(rule.Value)
+}
+
+// WithCustomGameRules - lets GamerulesUpdate send the given keys although the catalogue
+// does not know them, e.g. datapack or modded rules. Only a declared type is checked.
+func WithCustomGameRules(keys ...GameRuleKey) ClientOption {
+	return func(cfg *clientConfig) {
+		if cfg.customGameRules == nil {
+			cfg.customGameRules = map[GameRuleKey]bool{}
+		}
+		for _, k := range keys {
+			cfg.customGameRules[k] = true
+		}
+	}
+}
+
+// ============
+
+// GameRules - the game rules reported by the server.
+type GameRules []GameRule
+
+// Get - returns the rule with the given key.
+func (rules GameRules) Get(key GameRuleKey) (GameRule, bool) {
+	for _, r := range rules {
+		if r.Key == string(key) {
+			return r, true
+		}
+	}
+	return GameRule{}, false
+}
+
+// Bool - returns the value of a boolean rule.
+func (rules GameRules) Bool(key GameRuleKey) (bool, error) {
+	r, ok := rules.Get(key)
+	if !ok {
+		return false, fmt.Errorf("%w: '%s'", ErrUnknownGameRule, key)
+	}
+	return r.Boolean()
+}
+
+// Int - returns the value of an integer rule.
+func (rules GameRules) Int(key GameRuleKey) (int, error) {
+	r, ok := rules.Get(key)
+	if !ok {
+		return 0, fmt.Errorf("%w: '%s'", ErrUnknownGameRule, key)
+	}
+	return r.Integer()
+}
+
+// Map - returns the rule values keyed by rule key.
+func (rules GameRules) Map() map[GameRuleKey]string {
+	m := make(map[GameRuleKey]string, len(rules))
+	for _, r := range rules {
+		m[GameRuleKey(r.Key)] = r.Value
+	}
+	return m
+}
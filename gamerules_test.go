@@ -0,0 +1,129 @@
+package gomcsmp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+	"github.com/eterline/go-mc-smp/mcsmptest"
+)
+
+// packRule - a datapack rule the catalogue does not know.
+const packRule = "mypack:doCoolThing"
+
+func withPackRule(st *mcsmptest.State) {
+	st.GameRules = append(st.GameRules, gomcsmp.NewGameRuleBoolean(false, packRule))
+}
+
+func TestValidateGameRule(t *testing.T) {
+	tests := []struct {
+		name string
+		rule gomcsmp.GameRule
+		want error
+	}{
+		{"untyped", gomcsmp.NewGameRule("true", "keepInventory", gomcsmp.UntypedGameRule), nil},
+		{"typed", gomcsmp.GameRuleKeepInventory.Bool(true), nil},
+		{"wrong value", gomcsmp.NewGameRule("yes", "keepInventory", gomcsmp.UntypedGameRule), gomcsmp.ErrInvalidGameRule},
+		{"wrong type", gomcsmp.NewGameRuleInteger(1, "keepInventory"), gomcsmp.ErrInvalidGameRule},
+		{"out of range", gomcsmp.NewGameRule("-1", "randomTickSpeed", gomcsmp.UntypedGameRule), gomcsmp.ErrInvalidGameRule},
+		{"typo", gomcsmp.NewGameRule("true", "keepInventroy", gomcsmp.UntypedGameRule), gomcsmp.ErrUnknownGameRule},
+		{"typed typo", gomcsmp.NewGameRuleBoolean(true, "keepInventroy"), gomcsmp.ErrUnknownGameRule},
+		{"datapack rule", gomcsmp.NewGameRuleBoolean(true, packRule), gomcsmp.ErrUnknownGameRule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := gomcsmp.ValidateGameRule(tt.rule); !errors.Is(err, tt.want) || (err == nil) != (tt.want == nil) {
+				t.Fatalf("ValidateGameRule(%+v) = %v, want %v", tt.rule, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateLiveGameRule(t *testing.T) {
+	live := gomcsmp.GameRules{
+		gomcsmp.NewGameRuleBoolean(false, packRule),
+		gomcsmp.NewGameRule("3", "mypack:untyped", gomcsmp.UntypedGameRule),
+	}
+
+	tests := []struct {
+		name string
+		rule gomcsmp.GameRule
+		want error
+	}{
+		{"catalogue rule", gomcsmp.NewGameRule("true", "keepInventory", gomcsmp.UntypedGameRule), nil},
+		{"catalogue rule wrong value", gomcsmp.NewGameRule("yes", "keepInventory", gomcsmp.UntypedGameRule), gomcsmp.ErrInvalidGameRule},
+		{"live rule", gomcsmp.NewGameRule("true", packRule, gomcsmp.UntypedGameRule), nil},
+		{"live rule wrong value", gomcsmp.NewGameRule("7", packRule, gomcsmp.UntypedGameRule), gomcsmp.ErrInvalidGameRule},
+		{"live rule wrong type", gomcsmp.NewGameRuleInteger(7, packRule), gomcsmp.ErrInvalidGameRule},
+		{"live rule without type", gomcsmp.NewGameRule("anything", "mypack:untyped", gomcsmp.UntypedGameRule), nil},
+		{"typo", gomcsmp.NewGameRule("true", "keepInventroy", gomcsmp.UntypedGameRule), gomcsmp.ErrUnknownGameRule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := gomcsmp.ValidateLiveGameRule(tt.rule, live); !errors.Is(err, tt.want) || (err == nil) != (tt.want == nil) {
+				t.Fatalf("ValidateLiveGameRule(%+v) = %v, want %v", tt.rule, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGamerulesUpdateUnknownKey(t *testing.T) {
+	ctx := context.Background()
+	srv := mcsmptest.Start(t)
+	srv.Update(withPackRule)
+
+	client := srv.TestClient(t)
+	if _, err := client.GamerulesUpdate(ctx, gomcsmp.NewGameRule("true", "keepInventroy", gomcsmp.UntypedGameRule)); !errors.Is(err, gomcsmp.ErrUnknownGameRule) {
+		t.Fatalf("GamerulesUpdate(keepInventroy) error = %v, want ErrUnknownGameRule", err)
+	}
+	if _, err := client.GamerulesUpdate(ctx, gomcsmp.NewGameRule("true", packRule, gomcsmp.UntypedGameRule)); !errors.Is(err, gomcsmp.ErrUnknownGameRule) {
+		t.Fatalf("GamerulesUpdate(%s) error = %v, want ErrUnknownGameRule", packRule, err)
+	}
+	if n := srv.CallCount("minecraft:gamerules/update"); n != 0 {
+		t.Fatalf("gamerules/update called %d times, want 0", n)
+	}
+
+	custom := srv.TestClient(t, gomcsmp.WithCustomGameRules(packRule))
+	if _, err := custom.GamerulesUpdate(ctx, gomcsmp.NewGameRule("true", packRule, gomcsmp.UntypedGameRule)); err != nil {
+		t.Fatalf("GamerulesUpdate(%s) with WithCustomGameRules: %v", packRule, err)
+	}
+	if _, err := custom.GamerulesUpdate(ctx, gomcsmp.NewGameRule("true", "keepInventroy", gomcsmp.UntypedGameRule)); !errors.Is(err, gomcsmp.ErrUnknownGameRule) {
+		t.Fatalf("GamerulesUpdate(keepInventroy) with WithCustomGameRules error = %v, want ErrUnknownGameRule", err)
+	}
+}
+
+func TestGameRulePresetUnknownKey(t *testing.T) {
+	ctx := context.Background()
+	srv := mcsmptest.Start(t)
+	srv.Update(withPackRule)
+	client := srv.TestClient(t, gomcsmp.WithCustomGameRules(packRule))
+
+	typo := gomcsmp.NewGameRulePreset("typo",
+		gomcsmp.GameRuleKeepInventory.Bool(true),
+		gomcsmp.NewGameRule("true", "keepInventroy", gomcsmp.UntypedGameRule),
+	)
+	if err := typo.Validate(); !errors.Is(err, gomcsmp.ErrUnknownGameRule) {
+		t.Fatalf("Validate error = %v, want ErrUnknownGameRule", err)
+	}
+	if _, err := typo.Apply(ctx, client); !errors.Is(err, gomcsmp.ErrUnknownGameRule) {
+		t.Fatalf("Apply error = %v, want ErrUnknownGameRule", err)
+	}
+	if n := srv.CallCount("minecraft:gamerules/update"); n != 0 {
+		t.Fatalf("gamerules/update called %d times, want 0", n)
+	}
+
+	pack := gomcsmp.NewGameRulePreset("pack", gomcsmp.NewGameRule("true", packRule, gomcsmp.UntypedGameRule))
+	if err := pack.Validate(); !errors.Is(err, gomcsmp.ErrUnknownGameRule) {
+		t.Fatalf("Validate error = %v, want ErrUnknownGameRule", err)
+	}
+	report, err := pack.Apply(ctx, client)
+	if err != nil {
+		t.Fatalf("Apply of a live datapack rule: %v", err)
+	}
+	if len(report.Results) != 1 || report.Results[0].Status != gomcsmp.GameRuleApplied {
+		t.Fatalf("results = %+v, want the datapack rule applied", report.Results)
+	}
+}
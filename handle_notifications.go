@@ -4,7 +4,6 @@ import (
 	"context"
 
 	"github.com/eterline/go-mc-smp/internal/jsonrpc"
-	"github.com/eterline/go-mc-smp/internal/usage"
 )
 
 func notifyStream[T any](
@@ -58,157 +57,77 @@ func notifyStream[T any](
 // ===========
 
 func (rpc *RPCClient) NotifyPlayersJoined(ctx context.Context) <-chan Player {
-	method := usage.NewMethod("notification").
-		Add("players").
-		Add("joined").
-		String()
-
-	return notifyStream[Player](ctx, rpc.notify, method, false)
+	return notifyStream[Player](ctx, rpc.notify, eventPlayersJoined.Name, false)
 }
 
 func (rpc *RPCClient) NotifyPlayersLeft(ctx context.Context) <-chan Player {
-	method := usage.NewMethod("notification").
-		Add("players").
-		Add("left").
-		String()
-
-	return notifyStream[Player](ctx, rpc.notify, method, false)
+	return notifyStream[Player](ctx, rpc.notify, eventPlayersLeft.Name, false)
 }
 
 // ===========
 
 func (rpc *RPCClient) NotifyServerStarted(ctx context.Context) <-chan struct{} {
-	method := usage.NewMethod("notification").
-		Add("server").
-		Add("started").
-		String()
-
-	return notifyStream[struct{}](ctx, rpc.notify, method, true)
+	return notifyStream[struct{}](ctx, rpc.notify, eventServerStarted.Name, true)
 }
 
 func (rpc *RPCClient) NotifyServerStopping(ctx context.Context) <-chan struct{} {
-	method := usage.NewMethod("notification").
-		Add("server").
-		Add("stopping").
-		String()
-
-	return notifyStream[struct{}](ctx, rpc.notify, method, true)
+	return notifyStream[struct{}](ctx, rpc.notify, eventServerStopping.Name, true)
 }
 
 func (rpc *RPCClient) NotifyServerSaving(ctx context.Context) <-chan struct{} {
-	method := usage.NewMethod("notification").
-		Add("server").
-		Add("saving").
-		String()
-
-	return notifyStream[struct{}](ctx, rpc.notify, method, true)
+	return notifyStream[struct{}](ctx, rpc.notify, eventServerSaving.Name, true)
 }
 
 func (rpc *RPCClient) NotifyServerSaved(ctx context.Context) <-chan struct{} {
-	method := usage.NewMethod("notification").
-		Add("server").
-		Add("saved").
-		String()
-
-	return notifyStream[struct{}](ctx, rpc.notify, method, true)
+	return notifyStream[struct{}](ctx, rpc.notify, eventServerSaved.Name, true)
 }
 
 func (rpc *RPCClient) NotifyServerStatus(ctx context.Context) <-chan ServerState {
-	method := usage.NewMethod("notification").
-		Add("server").
-		Add("status").
-		String()
-
-	return notifyStream[ServerState](ctx, rpc.notify, method, false)
+	return notifyStream[ServerState](ctx, rpc.notify, eventServerStatus.Name, false)
 }
 
 // ===========
 
 func (rpc *RPCClient) NotifyGamerulesUpdates(ctx context.Context) <-chan GameRule {
-	method := usage.NewMethod("notification").
-		Add("gamerules").
-		Add("updated").
-		String()
-
-	return notifyStream[GameRule](ctx, rpc.notify, method, false)
+	return notifyStream[GameRule](ctx, rpc.notify, eventGamerulesUpdated.Name, false)
 }
 
 // ===========
 
 func (rpc *RPCClient) NotifyOperatorsAdded(ctx context.Context) <-chan Operator {
-	method := usage.NewMethod("notification").
-		Add("operators").
-		Add("added").
-		String()
-
-	return notifyStream[Operator](ctx, rpc.notify, method, false)
+	return notifyStream[Operator](ctx, rpc.notify, eventOperatorsAdded.Name, false)
 }
 
 func (rpc *RPCClient) NotifyOperatorsRemoved(ctx context.Context) <-chan Operator {
-	method := usage.NewMethod("notification").
-		Add("operators").
-		Add("removed").
-		String()
-
-	return notifyStream[Operator](ctx, rpc.notify, method, false)
+	return notifyStream[Operator](ctx, rpc.notify, eventOperatorsRemoved.Name, false)
 }
 
 // ===========
 
 func (rpc *RPCClient) NotifyAllowlistAdded(ctx context.Context) <-chan Player {
-	method := usage.NewMethod("notification").
-		Add("allowlist").
-		Add("added").
-		String()
-
-	return notifyStream[Player](ctx, rpc.notify, method, false)
+	return notifyStream[Player](ctx, rpc.notify, eventAllowlistAdded.Name, false)
 }
 
 func (rpc *RPCClient) NotifyAllowlistRemoved(ctx context.Context) <-chan Player {
-	method := usage.NewMethod("notification").
-		Add("allowlist").
-		Add("removed").
-		String()
-
-	return notifyStream[Player](ctx, rpc.notify, method, false)
+	return notifyStream[Player](ctx, rpc.notify, eventAllowlistRemoved.Name, false)
 }
 
 // ===========
 
 func (rpc *RPCClient) NotifyIPBansAdded(ctx context.Context) <-chan IncomingIPBan {
-	method := usage.NewMethod("notification").
-		Add("ip_bans").
-		Add("added").
-		String()
-
-	return notifyStream[IncomingIPBan](ctx, rpc.notify, method, false)
+	return notifyStream[IncomingIPBan](ctx, rpc.notify, eventIPBansAdded.Name, false)
 }
 
 func (rpc *RPCClient) NotifyIPBansRemoved(ctx context.Context) <-chan IncomingIPBan {
-	method := usage.NewMethod("notification").
-		Add("ip_bans").
-		Add("removed").
-		String()
-
-	return notifyStream[IncomingIPBan](ctx, rpc.notify, method, false)
+	return notifyStream[IncomingIPBan](ctx, rpc.notify, eventIPBansRemoved.Name, false)
 }
 
 // ===========
 
 func (rpc *RPCClient) NotifyBansAdded(ctx context.Context) <-chan UserBan {
-	method := usage.NewMethod("notification").
-		Add("bans").
-		Add("added").
-		String()
-
-	return notifyStream[UserBan](ctx, rpc.notify, method, false)
+	return notifyStream[UserBan](ctx, rpc.notify, eventBansAdded.Name, false)
 }
 
 func (rpc *RPCClient) NotifyBansRemoved(ctx context.Context) <-chan UserBan {
-	method := usage.NewMethod("notification").
-		Add("bans").
-		Add("removed").
-		String()
-
-	return notifyStream[UserBan](ctx, rpc.notify, method, false)
+	return notifyStream[UserBan](ctx, rpc.notify, eventBansRemoved.Name, false)
 }
@@ -22,15 +22,25 @@ func (e *jsonrpcError) Error() string {
 	return fmt.Sprintf("jsonrpc error: %s", e.data)
 }
 
+// Wrap - returns a copy of the error embedding err.
+// The sentinel itself is never modified, so it is safe for concurrent use.
 func (e *jsonrpcError) Wrap(err error) error {
-	e.embed = err
-	return e
+	return &jsonrpcError{
+		data:  e.data,
+		embed: err,
+	}
 }
 
 func (jerr *jsonrpcError) Unwrap() error {
 	return jerr.embed
 }
 
+// Is - matches errors created from the same sentinel.
+func (jerr *jsonrpcError) Is(target error) bool {
+	t, ok := target.(*jsonrpcError)
+	return ok && t.data == jerr.data
+}
+
 // ==================
 
 var (
@@ -40,8 +50,9 @@ var (
 	ErrDecodeResponse = newJsonrpcError("decode response error")
 	ErrEncodeRequest  = newJsonrpcError("encode request error")
 
-	ErrContext  = newJsonrpcError("context error")
-	ErrRpcClose = newJsonrpcError("close rpc error")
+	ErrContext    = newJsonrpcError("context error")
+	ErrRpcClose   = newJsonrpcError("close rpc error")
+	ErrConnClosed = newJsonrpcError("connection closed")
 
 	ErrResponseChannelClosed = newJsonrpcError("rpc response channel closed")
 	ErrRequestChannelClosed  = newJsonrpcError("rpc request channel closed")
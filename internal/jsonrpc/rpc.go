@@ -17,6 +17,9 @@ type Logger interface {
 	Error(v ...any)
 }
 
+// FrameObserver - receives every raw frame written (out=true) or read (out=false) by the client.
+type FrameObserver func(out bool, frame []byte)
+
 // ==========
 
 type JsonRPCClient struct {
@@ -31,14 +34,28 @@ type JsonRPCClient struct {
 	resMutex      sync.Mutex
 	notifications chan *RPCResponse
 
-	logger Logger
+	logger   Logger
+	observer atomic.Pointer[FrameObserver]
+
+	// done - closed when the reader stops; the connection is never re-established
+	done chan struct{}
+}
+
+// DialOption - configures the client before its read and write loops start.
+type DialOption func(c *JsonRPCClient)
+
+// WithObserver - observes raw frames from the first one on.
+func WithObserver(o FrameObserver) DialOption {
+	return func(c *JsonRPCClient) {
+		c.Observe(o)
+	}
 }
 
-func NewJsonRPCClient(url, token string, callTimeout time.Duration) (*JsonRPCClient, error) {
-	return NewJsonRPCClientWithContext(context.Background(), url, token, callTimeout)
+func NewJsonRPCClient(url, token string, callTimeout time.Duration, opts ...DialOption) (*JsonRPCClient, error) {
+	return NewJsonRPCClientWithContext(context.Background(), url, token, callTimeout, opts...)
 }
 
-func NewJsonRPCClientWithContext(ctx context.Context, url, token string, callTimeout time.Duration) (*JsonRPCClient, error) {
+func NewJsonRPCClientWithContext(ctx context.Context, url, token string, callTimeout time.Duration, opts ...DialOption) (*JsonRPCClient, error) {
 	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, authHeader(token))
 	if err != nil {
 		return nil, err
@@ -50,6 +67,11 @@ func NewJsonRPCClientWithContext(ctx context.Context, url, token string, callTim
 		responses:     make(map[int]chan *RPCResponse),
 		notifications: make(chan *RPCResponse, 16),
 		reqTimeout:    callTimeout,
+		done:          make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(client)
 	}
 
 	go client.writer()
@@ -62,19 +84,44 @@ func (c *JsonRPCClient) Logger(l Logger) {
 	c.logger = l
 }
 
+// Observe - sets the observer of raw frames. Nil removes it.
+func (c *JsonRPCClient) Observe(o FrameObserver) {
+	if o == nil {
+		c.observer.Store(nil)
+		return
+	}
+	c.observer.Store(&o)
+}
+
+func (c *JsonRPCClient) observe(out bool, frame []byte) {
+	if o := c.observer.Load(); o != nil {
+		(*o)(out, frame)
+	}
+}
+
 func (c *JsonRPCClient) nextID() int {
 	return int(atomic.AddInt32(&c.reqID, 1))
 }
 
 func (c *JsonRPCClient) writer() {
 	for req := range c.requests {
-		if err := c.conn.WriteJSON(req); err != nil {
+		frame, err := json.Marshal(req)
+		if err != nil {
+			c.Log().Error(ErrEncodeRequest.Wrap(err).Error())
+			continue
+		}
+
+		c.observe(true, frame)
+
+		if err := c.conn.WriteMessage(websocket.TextMessage, frame); err != nil {
 			c.Log().Error(ErrWriteRequest.Wrap(err).Error())
 		}
 	}
 }
 
 func (c *JsonRPCClient) reader() {
+	defer close(c.done)
+
 	for {
 		_, msg, err := c.conn.ReadMessage()
 		if err != nil {
@@ -82,6 +129,8 @@ func (c *JsonRPCClient) reader() {
 			return
 		}
 
+		c.observe(false, msg)
+
 		var resp RPCResponse
 
 		if err := json.Unmarshal(msg, &resp); err != nil {
@@ -113,6 +162,12 @@ func (c *JsonRPCClient) Call(method string, params ...any) (*RPCResponse, error)
 
 func (c *JsonRPCClient) CallWithContext(ctx context.Context, method string, params ...any) (*RPCResponse, error) {
 
+	select {
+	case <-c.done:
+		return nil, ErrConnClosed
+	default:
+	}
+
 	id := c.nextID()
 
 	if len(params) == 0 {
@@ -138,6 +193,8 @@ func (c *JsonRPCClient) CallWithContext(ctx context.Context, method string, para
 
 	select {
 	case c.requests <- req:
+	case <-c.done:
+		return nil, ErrConnClosed
 	case <-ctx.Done():
 		return nil, ErrContext.Wrap(ctx.Err())
 	}
@@ -153,6 +210,17 @@ func (c *JsonRPCClient) CallWithContext(ctx context.Context, method string, para
 		}
 		return resp, nil
 
+	case <-c.done:
+		// the response may have arrived right before the reader stopped
+		select {
+		case resp, ok := <-respCh:
+			if ok {
+				return resp, nil
+			}
+		default:
+		}
+		return nil, ErrConnClosed
+
 	case <-ctx.Done():
 		return nil, ErrContext.Wrap(ctx.Err())
 	}
@@ -58,12 +58,49 @@ func (r RPCResponse) ResultNotEmpty() error {
 	return nil
 }
 
+// Standard JSON-RPC error codes.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// ResponseError - the error object of a JSON-RPC response.
+// It is reachable with errors.As from the error returned by Err.
+type ResponseError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+
+	raw json.RawMessage
+}
+
+func (e *ResponseError) Error() string {
+	return string(e.raw)
+}
+
+// DataString - returns the data member when it is a JSON string, otherwise its raw JSON.
+func (e *ResponseError) DataString() string {
+	var s string
+	if err := json.Unmarshal(e.Data, &s); err == nil {
+		return s
+	}
+	return string(e.Data)
+}
+
 func (r RPCResponse) Err() error {
-	if r.Error != nil {
-		embedErr := fmt.Errorf("%v", r.Error)
-		return ErrResponseContains.Wrap(embedErr)
+	if r.Error == nil {
+		return nil
 	}
-	return nil
+
+	respErr := &ResponseError{raw: r.Error}
+	if err := json.Unmarshal(r.Error, respErr); err != nil {
+		// not an error object, keep the raw text only
+		return ErrResponseContains.Wrap(fmt.Errorf("%v", r.Error))
+	}
+	return ErrResponseContains.Wrap(respErr)
 }
 
 func DecodeRPCResult[T any](r *RPCResponse) (*T, error) {
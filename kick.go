@@ -0,0 +1,211 @@
+package gomcsmp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/eterline/go-mc-smp/internal/jsonrpc"
+)
+
+var ErrKickFailed = errors.New("kick failed")
+
+// KickStatus - the outcome of kicking one player.
+type KickStatus string
+
+const (
+	KickStatusKicked    KickStatus = "kicked"
+	KickStatusNotOnline KickStatus = "not_online"
+	KickStatusFailed    KickStatus = "failed"
+)
+
+// KickResult - the outcome for one requested player. Err is set for failed kicks.
+type KickResult struct {
+	Player Player
+	Status KickStatus
+	Err    error
+}
+
+// KickReport - the outcome of KickPlayers, one result per requested player
+// in request order. Online holds the players online after the kick,
+// it is nil when the checks were skipped.
+type KickReport struct {
+	Results []KickResult
+	Online  *PlayerRegistry
+}
+
+// Kicked - returns the players which were kicked.
+func (r *KickReport) Kicked() []Player {
+	return r.players(KickStatusKicked)
+}
+
+// NotOnline - returns the players which were not online.
+func (r *KickReport) NotOnline() []Player {
+	return r.players(KickStatusNotOnline)
+}
+
+// Err - returns the errors of all failed kicks joined, nil when none failed.
+func (r *KickReport) Err() error {
+	var errs []error
+	for _, res := range r.Results {
+		if res.Status == KickStatusFailed {
+			errs = append(errs, fmt.Errorf("%w '%s': %w", ErrKickFailed, res.Player.Name, res.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *KickReport) players(status KickStatus) []Player {
+	players := []Player{}
+	for _, res := range r.Results {
+		if res.Status == status {
+			players = append(players, res.Player)
+		}
+	}
+	return players
+}
+
+// ============
+
+type kickConfig struct {
+	precheck  bool
+	postcheck bool
+}
+
+// KickOption - configures KickPlayers.
+type KickOption func(cfg *kickConfig)
+
+// SkipKickPrecheck - sends the kick without first fetching the online players.
+// Players the server did not kick are then reported as not online.
+func SkipKickPrecheck() KickOption {
+	return func(cfg *kickConfig) {
+		cfg.precheck = false
+	}
+}
+
+// SkipKickPostcheck - does not fetch the online players after the kick
+// to confirm the kicked players are gone.
+func SkipKickPostcheck() KickOption {
+	return func(cfg *kickConfig) {
+		cfg.postcheck = false
+	}
+}
+
+// alreadyRetiredData - the exception the server reports as internal error data.
+const alreadyRetiredData = "java.lang.IllegalStateException: Already retired"
+
+// alreadyRetired - reports whether err is the "Already retired" kick race.
+//
+// The player may already be disconnecting, e.g. after leaving voluntarily, while the
+// server removes it from the world. Its entity is then already retired in the scheduler
+// and PlayerList.remove throws an IllegalStateException. The goal of the kick is
+// achieved anyway, so the error is treated as success.
+//
+// The error is matched on its code and data. Only when the server sent an error
+// which is not a JSON-RPC error object is the text searched as a fallback.
+func alreadyRetired(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var respErr *jsonrpc.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.Code == jsonrpc.CodeInternalError &&
+			strings.Contains(respErr.DataString(), alreadyRetiredData)
+	}
+
+	return errors.Is(err, jsonrpc.ErrResponseContains) && strings.Contains(err.Error(), "Already retired")
+}
+
+// KickPlayers - kicks players and reports the outcome for each of them.
+// By default the online players are fetched before the kick, so offline players
+// are not sent and online players missing from the kicked list are reported as failed.
+// They are fetched again after it, so players still online are reported as failed.
+// The report is returned even when the kick fails.
+func KickPlayers(ctx context.Context, api PlayersAPI, kicks []KickPlayer, opts ...KickOption) (*KickReport, error) {
+	cfg := kickConfig{precheck: true, postcheck: true}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	report := &KickReport{Results: make([]KickResult, len(kicks))}
+	for i, k := range kicks {
+		report.Results[i] = KickResult{Player: k.Player, Status: KickStatusNotOnline}
+	}
+
+	pending := make([]int, 0, len(kicks))
+	if cfg.precheck {
+		online, err := api.PlayersGet(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get players: %w", err)
+		}
+		report.Online = online
+
+		for i, k := range kicks {
+			if online.ContainsPlayer(k.Player) {
+				pending = append(pending, i)
+			}
+		}
+	} else {
+		for i := range kicks {
+			pending = append(pending, i)
+		}
+	}
+
+	if len(pending) == 0 {
+		return report, nil
+	}
+
+	toKick := make([]KickPlayer, len(pending))
+	for j, i := range pending {
+		toKick[j] = kicks[i]
+	}
+
+	kicked, err := api.PlayersKick(ctx, toKick...)
+	switch {
+	case alreadyRetired(err):
+		// the response carries no list, so every sent player counts as kicked
+		for _, i := range pending {
+			report.Results[i].Status = KickStatusKicked
+		}
+	case err != nil:
+		for _, i := range pending {
+			report.Results[i].Status = KickStatusFailed
+			report.Results[i].Err = err
+		}
+	default:
+		for _, i := range pending {
+			res := &report.Results[i]
+			switch {
+			case kicked.ContainsPlayer(res.Player):
+				res.Status = KickStatusKicked
+			case cfg.precheck:
+				// the precheck saw the player online, yet the server did not kick it
+				res.Status = KickStatusFailed
+				res.Err = errors.New("player was not kicked by the server")
+			}
+		}
+	}
+
+	if !cfg.postcheck {
+		report.Online = nil
+		return report, report.Err()
+	}
+
+	online, err := api.PlayersGet(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to get updated players: %w", err)
+	}
+	report.Online = online
+
+	for _, i := range pending {
+		res := &report.Results[i]
+		if res.Status == KickStatusKicked && online.ContainsPlayer(res.Player) {
+			res.Status = KickStatusFailed
+			res.Err = errors.New("player is still online")
+		}
+	}
+
+	return report, report.Err()
+}
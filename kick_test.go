@@ -0,0 +1,171 @@
+package gomcsmp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+	"github.com/eterline/go-mc-smp/mcsmptest"
+)
+
+func kickNames(names ...string) []gomcsmp.KickPlayer {
+	kicks := make([]gomcsmp.KickPlayer, len(names))
+	for i, name := range names {
+		kicks[i] = gomcsmp.NewKickPlayer(name, gomcsmp.Message{})
+	}
+	return kicks
+}
+
+func kickStatuses(r *gomcsmp.KickReport) []gomcsmp.KickStatus {
+	statuses := make([]gomcsmp.KickStatus, len(r.Results))
+	for i, res := range r.Results {
+		statuses[i] = res.Status
+	}
+	return statuses
+}
+
+func TestKickPlayers(t *testing.T) {
+	kicked, notOnline, failed := gomcsmp.KickStatusKicked, gomcsmp.KickStatusNotOnline, gomcsmp.KickStatusFailed
+
+	tests := []struct {
+		name    string
+		faults  []mcsmptest.Fault
+		opts    []gomcsmp.KickOption
+		want    []gomcsmp.KickStatus
+		wantErr bool
+		online  int
+	}{
+		{
+			name: "kicked and not online",
+			want: []gomcsmp.KickStatus{kicked, notOnline, kicked},
+		},
+		{
+			name:   "already retired",
+			faults: []mcsmptest.Fault{mcsmptest.AlreadyRetired()},
+			want:   []gomcsmp.KickStatus{kicked, notOnline, kicked},
+		},
+		{
+			name:    "server error",
+			faults:  []mcsmptest.Fault{mcsmptest.FailWith("minecraft:players/kick", "boom")},
+			want:    []gomcsmp.KickStatus{failed, notOnline, failed},
+			wantErr: true,
+			online:  2,
+		},
+		{
+			// the race error without the players actually leaving
+			name:    "still online",
+			faults:  []mcsmptest.Fault{mcsmptest.FailWith("minecraft:players/kick", "java.lang.IllegalStateException: Already retired")},
+			want:    []gomcsmp.KickStatus{failed, notOnline, failed},
+			wantErr: true,
+			online:  2,
+		},
+		{
+			name: "skip precheck",
+			opts: []gomcsmp.KickOption{gomcsmp.SkipKickPrecheck()},
+			want: []gomcsmp.KickStatus{kicked, notOnline, kicked},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := mcsmptest.Start(t, mcsmptest.WithFaultPlan(mcsmptest.NewFaultPlan(tt.faults...)))
+			client := srv.TestClient(t)
+			srv.Join(gomcsmp.NewPlayer("Steve"))
+			srv.Join(gomcsmp.NewPlayer("Alex"))
+
+			report, err := gomcsmp.KickPlayers(context.Background(), client, kickNames("Steve", "Notch", "Alex"), tt.opts...)
+			if report == nil {
+				t.Fatalf("KickPlayers returned no report: %v", err)
+			}
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("error = %v, want failure %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, gomcsmp.ErrKickFailed) {
+				t.Fatalf("error = %v, want ErrKickFailed", err)
+			}
+
+			got := kickStatuses(report)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("statuses = %v, want %v", got, tt.want)
+				}
+			}
+			if report.Online == nil || report.Online.Len() != tt.online {
+				t.Fatalf("online after kick = %v, want %d players", report.Online, tt.online)
+			}
+		})
+	}
+}
+
+func TestKickPlayersSkipsOffline(t *testing.T) {
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+
+	report, err := gomcsmp.KickPlayers(context.Background(), client, kickNames("Steve"))
+	if err != nil {
+		t.Fatalf("KickPlayers: %v", err)
+	}
+	if got := report.NotOnline(); len(got) != 1 || got[0].Name != "Steve" {
+		t.Fatalf("not online = %+v, want Steve", got)
+	}
+	if n := srv.CallCount("minecraft:players/kick"); n != 0 {
+		t.Fatalf("players/kick called %d times, want 0", n)
+	}
+}
+
+func TestPlayersKick(t *testing.T) {
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+	srv.Join(gomcsmp.NewPlayer("Steve"))
+
+	kicked, err := client.PlayersKick(context.Background(), kickNames("Steve", "Notch")...)
+	if err != nil {
+		t.Fatalf("PlayersKick: %v", err)
+	}
+	if !kicked.Contains("Steve") || kicked.Len() != 1 {
+		t.Fatalf("kicked = %+v, want Steve", kicked.Players())
+	}
+}
+
+// partialKick - reports every player online and kicks only the given ones.
+type partialKick struct {
+	online *gomcsmp.PlayerRegistry
+	kicks  *gomcsmp.PlayerRegistry
+}
+
+func (p partialKick) PlayersGet(context.Context) (*gomcsmp.PlayerRegistry, error) {
+	return p.online, nil
+}
+
+func (p partialKick) PlayersKick(context.Context, ...gomcsmp.KickPlayer) (*gomcsmp.PlayerRegistry, error) {
+	return p.kicks, nil
+}
+
+func TestKickPlayersNotKickedByServer(t *testing.T) {
+	api := partialKick{
+		online: gomcsmp.NewPlayerRegistryNames("Steve", "Alex"),
+		kicks:  gomcsmp.NewPlayerRegistryNames("Steve"),
+	}
+	want := []gomcsmp.KickStatus{gomcsmp.KickStatusKicked, gomcsmp.KickStatusFailed}
+
+	report, err := gomcsmp.KickPlayers(context.Background(), api, kickNames("Steve", "Alex"), gomcsmp.SkipKickPostcheck())
+	if !errors.Is(err, gomcsmp.ErrKickFailed) {
+		t.Fatalf("error = %v, want ErrKickFailed", err)
+	}
+	if got := kickStatuses(report); got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("statuses = %v, want %v", got, want)
+	}
+	if report.Results[1].Err == nil {
+		t.Fatal("failed result carries no error")
+	}
+
+	// without the precheck nothing shows the player was online
+	report, err = gomcsmp.KickPlayers(context.Background(), api, kickNames("Steve", "Alex"), gomcsmp.SkipKickPrecheck(), gomcsmp.SkipKickPostcheck())
+	if err != nil {
+		t.Fatalf("KickPlayers without precheck: %v", err)
+	}
+	if got := report.NotOnline(); len(got) != 1 || got[0].Name != "Alex" {
+		t.Fatalf("not online = %+v, want Alex", got)
+	}
+}
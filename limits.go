@@ -0,0 +1,267 @@
+package gomcsmp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrLimitWaitExceeded - returned when a call could not pass the client limits within the allowed wait.
+var ErrLimitWaitExceeded = errors.New("client limit wait exceeded")
+
+// MethodGroup - a class of RPC methods sharing the same limits.
+type MethodGroup string
+
+const (
+	// ReadMethods - methods which only read server state.
+	ReadMethods MethodGroup = "read"
+	// MutationMethods - methods which change server state.
+	MutationMethods MethodGroup = "mutation"
+)
+
+// Limits - client-side call limits.
+// Rate is the number of calls per second refilled into a token bucket of Burst size.
+// MaxInFlight caps the number of concurrent calls.
+// Zero values disable the corresponding limit.
+type Limits struct {
+	Rate        float64
+	Burst       int
+	MaxInFlight int
+}
+
+func (l Limits) enabled() bool {
+	return l.Rate > 0 || l.MaxInFlight > 0
+}
+
+// ================
+
+// WithLimits - applies the given limits to all RPC calls.
+func WithLimits(l Limits) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.limits = l
+	}
+}
+
+// WithGroupLimits - applies the given limits to the calls of one method group.
+// Group limits are enforced in addition to the global ones.
+func WithGroupLimits(group MethodGroup, l Limits) ClientOption {
+	return func(cfg *clientConfig) {
+		if cfg.groupLimits == nil {
+			cfg.groupLimits = make(map[MethodGroup]Limits)
+		}
+		cfg.groupLimits[group] = l
+	}
+}
+
+// WithLimitWait - sets the maximum time a call may wait for the client limits.
+// Zero means waiting is bounded only by the call context.
+func WithLimitWait(max time.Duration) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.limitWait = max
+	}
+}
+
+// ================
+
+type callLimiter struct {
+	maxWait time.Duration
+	global  *limitSet
+	groups  map[MethodGroup]*limitSet
+}
+
+func newCallLimiter(cfg *clientConfig) *callLimiter {
+	l := &callLimiter{
+		maxWait: cfg.limitWait,
+		groups:  make(map[MethodGroup]*limitSet, len(cfg.groupLimits)),
+	}
+
+	if cfg.limits.enabled() {
+		l.global = newLimitSet(cfg.limits)
+	}
+
+	for group, gl := range cfg.groupLimits {
+		if gl.enabled() {
+			l.groups[group] = newLimitSet(gl)
+		}
+	}
+
+	if l.global == nil && len(l.groups) == 0 {
+		return nil
+	}
+
+	return l
+}
+
+// acquire - waits until the method may be called.
+// The returned release func must be called once the call completes.
+func (l *callLimiter) acquire(ctx context.Context, method string) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	waitCtx := ctx
+	if l.maxWait > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, l.maxWait)
+		defer cancel()
+	}
+
+	sets := make([]*limitSet, 0, 2)
+	if l.global != nil {
+		sets = append(sets, l.global)
+	}
+	if gs, ok := l.groups[methodGroupOf(method)]; ok {
+		sets = append(sets, gs)
+	}
+
+	started := time.Now()
+	acquired := make([]*limitSet, 0, len(sets))
+
+	release = func() {
+		for _, s := range acquired {
+			s.release()
+		}
+	}
+
+	for _, s := range sets {
+		if err := s.acquire(waitCtx, l.maxWait); err != nil {
+			// the call is not made, so the earlier sets get their tokens back too
+			for _, a := range acquired {
+				a.abort()
+			}
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: method '%s' waited %s", ErrLimitWaitExceeded, method, time.Since(started).Round(time.Millisecond))
+		}
+		acquired = append(acquired, s)
+	}
+
+	return release, nil
+}
+
+// ================
+
+type limitSet struct {
+	bucket *tokenBucket
+	sem    chan struct{}
+}
+
+func newLimitSet(l Limits) *limitSet {
+	s := &limitSet{}
+	if l.Rate > 0 {
+		s.bucket = newTokenBucket(l.Rate, l.Burst)
+	}
+	if l.MaxInFlight > 0 {
+		s.sem = make(chan struct{}, l.MaxInFlight)
+	}
+	return s
+}
+
+func (s *limitSet) acquire(ctx context.Context, maxWait time.Duration) error {
+	if s.bucket != nil {
+		if err := s.bucket.wait(ctx, maxWait); err != nil {
+			return err
+		}
+	}
+
+	if s.sem != nil {
+		select {
+		case s.sem <- struct{}{}:
+		case <-ctx.Done():
+			if s.bucket != nil {
+				s.bucket.cancel()
+			}
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (s *limitSet) release() {
+	if s.sem != nil {
+		<-s.sem
+	}
+}
+
+// abort - undoes a successful acquire for a call which was never made.
+func (s *limitSet) abort() {
+	if s.bucket != nil {
+		s.bucket.cancel()
+	}
+	s.release()
+}
+
+// ================
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve - takes a token and returns the delay before it may be used.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel - returns a reserved token which was not used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.tokens+1, b.burst)
+}
+
+func (b *tokenBucket) wait(ctx context.Context, maxWait time.Duration) error {
+	delay := b.reserve(time.Now())
+	if delay == 0 {
+		return nil
+	}
+
+	if maxWait > 0 && delay > maxWait {
+		b.cancel()
+		return context.DeadlineExceeded
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
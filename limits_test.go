@@ -0,0 +1,152 @@
+package gomcsmp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func testLimiter(opts ...ClientOption) *callLimiter {
+	cfg := defaultClientConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return newCallLimiter(cfg)
+}
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(10, 2)
+	now := b.last
+
+	if d := b.reserve(now); d != 0 {
+		t.Fatalf("first reserve delay = %s, want 0", d)
+	}
+	if d := b.reserve(now); d != 0 {
+		t.Fatalf("second reserve delay = %s, want 0", d)
+	}
+	if d := b.reserve(now); d != 100*time.Millisecond {
+		t.Fatalf("third reserve delay = %s, want 100ms", d)
+	}
+
+	b.cancel()
+	if d := b.reserve(now); d != 100*time.Millisecond {
+		t.Fatalf("reserve after cancel delay = %s, want 100ms", d)
+	}
+
+	// refilled tokens never exceed the burst
+	if d := b.reserve(now.Add(time.Hour)); d != 0 {
+		t.Fatalf("reserve after refill delay = %s, want 0", d)
+	}
+	b.cancel()
+	b.cancel()
+	if b.tokens != b.burst {
+		t.Fatalf("tokens = %v after cancel, want the burst %v", b.tokens, b.burst)
+	}
+}
+
+func TestTokenBucketWaitTooLong(t *testing.T) {
+	b := newTokenBucket(1, 1)
+	b.reserve(time.Now())
+
+	err := b.wait(context.Background(), 10*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("wait error = %v, want context.DeadlineExceeded", err)
+	}
+	if b.tokens < -0.01 {
+		t.Fatalf("tokens = %v, want the refused token returned", b.tokens)
+	}
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	if l := testLimiter(); l != nil {
+		t.Fatal("limiter without limits is not nil")
+	}
+
+	var l *callLimiter
+	release, err := l.acquire(context.Background(), "minecraft:players")
+	if err != nil {
+		t.Fatalf("nil limiter acquire: %v", err)
+	}
+	release()
+}
+
+func TestLimiterInFlight(t *testing.T) {
+	l := testLimiter(WithLimits(Limits{MaxInFlight: 1}), WithLimitWait(20*time.Millisecond))
+
+	release, err := l.acquire(context.Background(), "minecraft:players")
+	if err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+
+	_, err = l.acquire(context.Background(), "minecraft:players")
+	if !errors.Is(err, ErrLimitWaitExceeded) {
+		t.Fatalf("second acquire error = %v, want ErrLimitWaitExceeded", err)
+	}
+
+	release()
+	release, err = l.acquire(context.Background(), "minecraft:players")
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	release()
+}
+
+func TestLimiterCallerContext(t *testing.T) {
+	l := testLimiter(WithLimits(Limits{MaxInFlight: 1}))
+
+	release, _ := l.acquire(context.Background(), "minecraft:players")
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := l.acquire(ctx, "minecraft:players")
+	if !errors.Is(err, context.Canceled) || errors.Is(err, ErrLimitWaitExceeded) {
+		t.Fatalf("acquire error = %v, want context.Canceled", err)
+	}
+}
+
+func TestLimitSetReturnsTokenOnSlotTimeout(t *testing.T) {
+	s := newLimitSet(Limits{Rate: 1, Burst: 1, MaxInFlight: 1})
+	s.sem <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := s.acquire(ctx, 0); err == nil {
+		t.Fatal("acquire with a full semaphore succeeded")
+	}
+	if s.bucket.tokens < 0.99 {
+		t.Fatalf("tokens = %v, want the token returned", s.bucket.tokens)
+	}
+}
+
+func TestLimiterAbortsEarlierSets(t *testing.T) {
+	l := testLimiter(
+		WithLimits(Limits{Rate: 1, Burst: 1, MaxInFlight: 1}),
+		WithGroupLimits(MutationMethods, Limits{MaxInFlight: 1}),
+		WithLimitWait(10*time.Millisecond),
+	)
+	group := l.groups[MutationMethods]
+	group.sem <- struct{}{}
+
+	_, err := l.acquire(context.Background(), "minecraft:allowlist/add")
+	if !errors.Is(err, ErrLimitWaitExceeded) {
+		t.Fatalf("acquire error = %v, want ErrLimitWaitExceeded", err)
+	}
+
+	if n := len(l.global.sem); n != 0 {
+		t.Fatalf("global in-flight = %d, want the slot released", n)
+	}
+	if l.global.bucket.tokens < 0.99 {
+		t.Fatalf("global tokens = %v, want the token returned", l.global.bucket.tokens)
+	}
+
+	// read methods are not held back by the mutation group
+	release, err := l.acquire(context.Background(), "minecraft:allowlist")
+	if err != nil {
+		t.Fatalf("read acquire: %v", err)
+	}
+	release()
+}
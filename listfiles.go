@@ -0,0 +1,372 @@
+package gomcsmp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Vanilla list file names inside a server directory.
+const (
+	OpsFile           = "ops.json"
+	WhitelistFile     = "whitelist.json"
+	BannedPlayersFile = "banned-players.json"
+	BannedIPsFile     = "banned-ips.json"
+)
+
+// defaults vanilla writes for bans issued from the console
+const (
+	vanillaBanSource = "Server"
+	vanillaBanReason = "Banned by an operator."
+)
+
+// vanilla file records; the server drops profiles without a UUID when loading them
+type (
+	vanillaProfile struct {
+		UUID *uuid.UUID `json:"uuid,omitempty"`
+		Name string     `json:"name"`
+	}
+
+	vanillaOp struct {
+		UUID                *uuid.UUID `json:"uuid,omitempty"`
+		Name                string     `json:"name"`
+		Level               int        `json:"level"`
+		BypassesPlayerLimit bool       `json:"bypassesPlayerLimit"`
+	}
+
+	vanillaUserBan struct {
+		UUID    *uuid.UUID `json:"uuid,omitempty"`
+		Name    string     `json:"name"`
+		Created string     `json:"created"`
+		Source  string     `json:"source"`
+		Expires BanExpiry  `json:"expires"`
+		Reason  string     `json:"reason"`
+	}
+
+	vanillaIPBan struct {
+		IP      string    `json:"ip"`
+		Created string    `json:"created"`
+		Source  string    `json:"source"`
+		Expires BanExpiry `json:"expires"`
+		Reason  string    `json:"reason"`
+	}
+)
+
+func readVanillaList[T any](rd io.Reader, file string) ([]T, error) {
+	entries := []T{}
+	if err := json.NewDecoder(rd).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("read %s: %w", file, err)
+	}
+	return entries, nil
+}
+
+func writeVanillaList[T any](w io.Writer, file string, entries []T) error {
+	if entries == nil {
+		entries = []T{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(entries); err != nil {
+		return fmt.Errorf("write %s: %w", file, err)
+	}
+	return nil
+}
+
+// vanillaCreated - the creation date written for a ban entry,
+// the kept one or now for entries which have none.
+func vanillaCreated(created string, now time.Time) string {
+	if created != "" {
+		return created
+	}
+	return now.Format(BanExpiryLayout)
+}
+
+// ============
+
+// ReadOpsFile - reads operators in the ops.json format.
+func ReadOpsFile(rd io.Reader) ([]Operator, error) {
+	entries, err := readVanillaList[vanillaOp](rd, OpsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]Operator, len(entries))
+	for i, e := range entries {
+		ops[i] = NewOperator(Player{Name: e.Name, ID: e.UUID}, e.Level, e.BypassesPlayerLimit)
+	}
+	return ops, nil
+}
+
+// WriteOpsFile - writes operators in the ops.json format.
+func WriteOpsFile(w io.Writer, ops []Operator) error {
+	entries := make([]vanillaOp, len(ops))
+	for i, op := range ops {
+		entries[i] = vanillaOp{
+			UUID:                op.Player.ID,
+			Name:                op.Player.Name,
+			Level:               op.Permission,
+			BypassesPlayerLimit: op.BypassesPlayerLimit,
+		}
+	}
+	return writeVanillaList(w, OpsFile, entries)
+}
+
+// ReadWhitelistFile - reads players in the whitelist.json format.
+func ReadWhitelistFile(rd io.Reader) ([]Player, error) {
+	entries, err := readVanillaList[vanillaProfile](rd, WhitelistFile)
+	if err != nil {
+		return nil, err
+	}
+
+	players := make([]Player, len(entries))
+	for i, e := range entries {
+		players[i] = Player{Name: e.Name, ID: e.UUID}
+	}
+	return players, nil
+}
+
+// WriteWhitelistFile - writes players in the whitelist.json format.
+func WriteWhitelistFile(w io.Writer, players []Player) error {
+	entries := make([]vanillaProfile, len(players))
+	for i, p := range players {
+		entries[i] = vanillaProfile{UUID: p.ID, Name: p.Name}
+	}
+	return writeVanillaList(w, WhitelistFile, entries)
+}
+
+// ReadBannedPlayersFile - reads player bans in the banned-players.json format.
+// The creation date is kept in Created as written in the file.
+func ReadBannedPlayersFile(rd io.Reader) ([]UserBan, error) {
+	entries, err := readVanillaList[vanillaUserBan](rd, BannedPlayersFile)
+	if err != nil {
+		return nil, err
+	}
+
+	bans := make([]UserBan, len(entries))
+	for i, e := range entries {
+		bans[i] = UserBan{
+			Reason:  e.Reason,
+			Expires: e.Expires,
+			Source:  e.Source,
+			Player:  Player{Name: e.Name, ID: e.UUID},
+			Created: e.Created,
+		}
+	}
+	return bans, nil
+}
+
+// WriteBannedPlayersFile - writes player bans in the banned-players.json format.
+// Created is written unchanged, entries without it are dated now.
+// An empty source or reason gets the vanilla default.
+func WriteBannedPlayersFile(w io.Writer, bans []UserBan) error {
+	now := time.Now()
+
+	entries := make([]vanillaUserBan, len(bans))
+	for i, b := range bans {
+		entries[i] = vanillaUserBan{
+			UUID:    b.Player.ID,
+			Name:    b.Player.Name,
+			Created: vanillaCreated(b.Created, now),
+			Source:  orDefault(b.Source, vanillaBanSource),
+			Expires: b.Expires,
+			Reason:  orDefault(b.Reason, vanillaBanReason),
+		}
+	}
+	return writeVanillaList(w, BannedPlayersFile, entries)
+}
+
+// ReadBannedIPsFile - reads IP bans in the banned-ips.json format.
+// The creation date is kept in Created as written in the file.
+func ReadBannedIPsFile(rd io.Reader) ([]IPBan, error) {
+	entries, err := readVanillaList[vanillaIPBan](rd, BannedIPsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	bans := make([]IPBan, len(entries))
+	for i, e := range entries {
+		bans[i] = IPBan{
+			Reason:  e.Reason,
+			Expires: e.Expires,
+			IP:      e.IP,
+			Source:  e.Source,
+			Created: e.Created,
+		}
+	}
+	return bans, nil
+}
+
+// WriteBannedIPsFile - writes IP bans in the banned-ips.json format.
+// Created is written unchanged, entries without it are dated now.
+// An empty source or reason gets the vanilla default.
+func WriteBannedIPsFile(w io.Writer, bans []IPBan) error {
+	now := time.Now()
+
+	entries := make([]vanillaIPBan, len(bans))
+	for i, b := range bans {
+		entries[i] = vanillaIPBan{
+			IP:      b.IP,
+			Created: vanillaCreated(b.Created, now),
+			Source:  orDefault(b.Source, vanillaBanSource),
+			Expires: b.Expires,
+			Reason:  orDefault(b.Reason, vanillaBanReason),
+		}
+	}
+	return writeVanillaList(w, BannedIPsFile, entries)
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// ============
+
+// VanillaLists - the access lists of a server directory.
+type VanillaLists struct {
+	Operators []Operator
+	Allowlist []Player
+	Bans      []UserBan
+	IPBans    []IPBan
+}
+
+// LoadVanillaLists - reads ops.json, whitelist.json, banned-players.json and
+// banned-ips.json from a server directory. Missing files give empty lists.
+func LoadVanillaLists(dir string) (*VanillaLists, error) {
+	var (
+		l   VanillaLists
+		err error
+	)
+
+	if l.Operators, err = loadVanillaFile(dir, OpsFile, ReadOpsFile); err != nil {
+		return nil, err
+	}
+	if l.Allowlist, err = loadVanillaFile(dir, WhitelistFile, ReadWhitelistFile); err != nil {
+		return nil, err
+	}
+	if l.Bans, err = loadVanillaFile(dir, BannedPlayersFile, ReadBannedPlayersFile); err != nil {
+		return nil, err
+	}
+	if l.IPBans, err = loadVanillaFile(dir, BannedIPsFile, ReadBannedIPsFile); err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+func loadVanillaFile[T any](dir, file string, read func(io.Reader) ([]T, error)) ([]T, error) {
+	f, err := os.Open(filepath.Join(dir, file))
+	if errors.Is(err, os.ErrNotExist) {
+		return []T{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", file, err)
+	}
+	defer f.Close()
+
+	return read(f)
+}
+
+// Save - writes the four list files into a server directory, replacing them.
+func (l *VanillaLists) Save(dir string) error {
+	files := []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{OpsFile, func(w io.Writer) error { return WriteOpsFile(w, l.Operators) }},
+		{WhitelistFile, func(w io.Writer) error { return WriteWhitelistFile(w, l.Allowlist) }},
+		{BannedPlayersFile, func(w io.Writer) error { return WriteBannedPlayersFile(w, l.Bans) }},
+		{BannedIPsFile, func(w io.Writer) error { return WriteBannedIPsFile(w, l.IPBans) }},
+	}
+
+	for _, file := range files {
+		f, err := os.Create(filepath.Join(dir, file.name))
+		if err != nil {
+			return fmt.Errorf("create %s: %w", file.name, err)
+		}
+		if err := file.write(f); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("write %s: %w", file.name, err)
+		}
+	}
+	return nil
+}
+
+// PullVanillaLists - reads the access lists of a live server.
+func PullVanillaLists(ctx context.Context, api AccessListsAPI) (*VanillaLists, error) {
+	ops, err := api.OperatorsGet(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operators: %w", err)
+	}
+
+	allowlist, err := api.AllowlistGet(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowlist: %w", err)
+	}
+
+	bans, err := api.BansGet(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bans: %w", err)
+	}
+
+	ipBans, err := api.IPBansGet(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ip bans: %w", err)
+	}
+
+	return &VanillaLists{
+		Operators: ops,
+		Allowlist: allowlist.Players(),
+		Bans:      bans,
+		IPBans:    ipBans,
+	}, nil
+}
+
+// Push - replaces the access lists of a live server with the lists.
+// Expired bans are left out, as the server would drop them anyway.
+// Every list is attempted, failures are returned joined.
+func (l *VanillaLists) Push(ctx context.Context, api AccessListsAPI) error {
+	var errs []error
+
+	if err := api.OperatorsSet(ctx, l.Operators...); err != nil {
+		errs = append(errs, fmt.Errorf("failed to set operators: %w", err))
+	}
+	if err := api.AllowlistSet(ctx, l.Allowlist...); err != nil {
+		errs = append(errs, fmt.Errorf("failed to set allowlist: %w", err))
+	}
+
+	bans := make([]UserBan, 0, len(l.Bans))
+	for _, b := range l.Bans {
+		if !b.Expires.Expired() {
+			bans = append(bans, b)
+		}
+	}
+	if err := api.BansSet(ctx, bans...); err != nil {
+		errs = append(errs, fmt.Errorf("failed to set bans: %w", err))
+	}
+
+	ipBans := make([]IPBan, 0, len(l.IPBans))
+	for _, b := range l.IPBans {
+		if !b.Expires.Expired() {
+			ipBans = append(ipBans, b)
+		}
+	}
+	if err := api.IPBansSet(ctx, ipBans...); err != nil {
+		errs = append(errs, fmt.Errorf("failed to set ip bans: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,146 @@
+package gomcsmp_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+	"github.com/eterline/go-mc-smp/mcsmptest"
+)
+
+const bannedPlayersJSON = `[
+  {
+    "uuid": "00000000-0000-0000-0000-000000000001",
+    "name": "Steve",
+    "created": "2024-01-02 03:04:05 +0000",
+    "source": "Server",
+    "expires": "forever",
+    "reason": "Griefing"
+  }
+]`
+
+const bannedIPsJSON = `[
+  {
+    "ip": "192.168.0.10",
+    "created": "2024-01-02 03:04:05 +0000",
+    "source": "Alex",
+    "expires": "2099-01-01 00:00:00 +0000",
+    "reason": "Spam"
+  }
+]`
+
+func TestVanillaListsRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, gomcsmp.BannedPlayersFile), []byte(bannedPlayersJSON), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, gomcsmp.BannedIPsFile), []byte(bannedIPsJSON), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	lists, err := gomcsmp.LoadVanillaLists(dir)
+	if err != nil {
+		t.Fatalf("LoadVanillaLists: %v", err)
+	}
+	if len(lists.Operators) != 0 || len(lists.Allowlist) != 0 {
+		t.Fatalf("missing files gave %d operators and %d allowlisted", len(lists.Operators), len(lists.Allowlist))
+	}
+	if len(lists.Bans) != 1 || lists.Bans[0].Created != "2024-01-02 03:04:05 +0000" {
+		t.Fatalf("bans = %+v", lists.Bans)
+	}
+	if !lists.Bans[0].Expires.IsPermanent() || lists.Bans[0].Player.Name != "Steve" {
+		t.Fatalf("ban = %+v", lists.Bans[0])
+	}
+
+	out := t.TempDir()
+	if err := lists.Save(out); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	for file, want := range map[string]string{
+		gomcsmp.BannedPlayersFile: bannedPlayersJSON,
+		gomcsmp.BannedIPsFile:     bannedIPsJSON,
+		gomcsmp.OpsFile:           "[]",
+		gomcsmp.WhitelistFile:     "[]",
+	} {
+		got, err := os.ReadFile(filepath.Join(out, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.TrimSpace(string(got)) != want {
+			t.Errorf("%s:\n%s\nwant:\n%s", file, got, want)
+		}
+	}
+}
+
+func TestWriteBannedPlayersFileDefaults(t *testing.T) {
+	before := time.Now().Truncate(time.Second)
+
+	var b strings.Builder
+	ban := gomcsmp.NewPermanentUserBan(playerWithID("Alex", idAlex), "", "")
+	if err := gomcsmp.WriteBannedPlayersFile(&b, []gomcsmp.UserBan{ban}); err != nil {
+		t.Fatalf("WriteBannedPlayersFile: %v", err)
+	}
+
+	var entries []map[string]string
+	if err := json.Unmarshal([]byte(b.String()), &entries); err != nil {
+		t.Fatal(err)
+	}
+	e := entries[0]
+	if e["source"] != "Server" || e["reason"] != "Banned by an operator." || e["expires"] != "forever" {
+		t.Fatalf("entry = %v", e)
+	}
+
+	created, err := time.Parse(gomcsmp.BanExpiryLayout, e["created"])
+	if err != nil || created.Before(before) {
+		t.Fatalf("created = %q, %v, want now", e["created"], err)
+	}
+}
+
+func TestVanillaListsPush(t *testing.T) {
+	ctx := context.Background()
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+
+	active := gomcsmp.NewPermanentUserBan(playerWithID("Steve", idSteve), "Griefing", "Server")
+	active.Created = "2024-01-02 03:04:05 +0000"
+	expired := gomcsmp.UserBan{
+		Player:  playerWithID("Alex", idAlex),
+		Expires: gomcsmp.ExpiresAt(time.Now().Add(-time.Hour)),
+		Reason:  "Spam",
+	}
+	expiredIP := gomcsmp.IPBan{IP: "192.168.0.10", Expires: gomcsmp.ExpiresAt(time.Now().Add(-time.Hour))}
+
+	lists := &gomcsmp.VanillaLists{
+		Allowlist: []gomcsmp.Player{playerWithID("Steve", idSteve)},
+		Bans:      []gomcsmp.UserBan{active, expired},
+		IPBans:    []gomcsmp.IPBan{expiredIP},
+	}
+	if err := lists.Push(ctx, client); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+
+	st := srv.State()
+	if len(st.Bans) != 1 || st.Bans[0].Player.Name != "Steve" {
+		t.Fatalf("server bans = %+v, want Steve only", st.Bans)
+	}
+	if len(st.IPBans) != 0 {
+		t.Fatalf("server ip bans = %+v, want none", st.IPBans)
+	}
+	if len(st.Allowlist) != 1 || st.Allowlist[0].Name != "Steve" {
+		t.Fatalf("server allowlist = %+v", st.Allowlist)
+	}
+
+	pulled, err := gomcsmp.PullVanillaLists(ctx, client)
+	if err != nil {
+		t.Fatalf("PullVanillaLists: %v", err)
+	}
+	if len(pulled.Bans) != 1 || pulled.Bans[0].Reason != "Griefing" || pulled.Bans[0].Created != "" {
+		t.Fatalf("pulled bans = %+v", pulled.Bans)
+	}
+}
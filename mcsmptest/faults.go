@@ -0,0 +1,173 @@
+package mcsmptest
+
+import (
+	"sync"
+	"time"
+)
+
+// Fault - a failure injected into the server's handling of matching requests.
+//
+// Method is the full method name (e.g. "minecraft:players/kick"), empty matches every method.
+// Skip lets the first Skip matching requests through untouched, later rules included,
+// Times limits the fault to the next Times matching requests (zero means every one).
+type Fault struct {
+	Method string
+	Skip   int
+	Times  int
+
+	// Delay - postpones the response. Other requests keep being served meanwhile.
+	Delay time.Duration
+	// Drop - never sends the response.
+	Drop bool
+	// Reorder - holds the response back until the next response on the connection was sent.
+	Reorder bool
+	// Malformed - sends a frame which is not valid JSON instead of the response.
+	Malformed bool
+	// Disconnect - closes the connection as soon as the request is received.
+	Disconnect bool
+	// Error - responds with this error instead of the result.
+	Error *Error
+	// Process - applies the request to the server state even when responding with Error.
+	Process bool
+}
+
+// DelayResponse - delays responses to the method by d.
+func DelayResponse(method string, d time.Duration) Fault {
+	return Fault{Method: method, Delay: d}
+}
+
+// DropResponse - drops responses to the method.
+func DropResponse(method string) Fault {
+	return Fault{Method: method, Drop: true}
+}
+
+// ReorderResponse - sends the response to the method after the next response.
+func ReorderResponse(method string) Fault {
+	return Fault{Method: method, Reorder: true}
+}
+
+// MalformedResponse - replaces responses to the method with invalid JSON.
+func MalformedResponse(method string) Fault {
+	return Fault{Method: method, Malformed: true}
+}
+
+// DisconnectOn - drops the connection when the method is called.
+func DisconnectOn(method string) Fault {
+	return Fault{Method: method, Disconnect: true}
+}
+
+// FailWith - responds to the method with an internal server error carrying the given data.
+func FailWith(method string, data string) Fault {
+	return Fault{
+		Method: method,
+		Error:  &Error{Code: CodeInternalError, Message: "Internal error", Data: data},
+	}
+}
+
+// AlreadyRetired - reproduces the "Already retired" exception thrown by players/kick
+// when a player is disconnecting at the same moment. The players are still removed.
+func AlreadyRetired() Fault {
+	f := FailWith(methodName("players", "kick"), "java.lang.IllegalStateException: Already retired")
+	f.Process = true
+	return f
+}
+
+// ============
+
+// FaultPlan - an ordered, scriptable list of faults.
+// The first rule matching a request decides its fault, even while it is skipping.
+type FaultPlan struct {
+	mu    sync.Mutex
+	rules []*Fault
+}
+
+// NewFaultPlan - creates a plan from the given faults.
+func NewFaultPlan(faults ...Fault) *FaultPlan {
+	p := &FaultPlan{}
+	for _, f := range faults {
+		p.Add(f)
+	}
+	return p
+}
+
+// Add - appends a fault to the plan.
+func (p *FaultPlan) Add(f Fault) *FaultPlan {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rules = append(p.rules, &f)
+	return p
+}
+
+// Clear - removes all faults from the plan.
+func (p *FaultPlan) Clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rules = nil
+}
+
+// Len - returns the number of faults which may still fire.
+func (p *FaultPlan) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.rules)
+}
+
+// take - returns the fault for a request to the method, if any.
+func (p *FaultPlan) take(method string) (Fault, bool) {
+	if p == nil {
+		return Fault{}, false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, r := range p.rules {
+		if r.Method != "" && r.Method != method {
+			continue
+		}
+
+		// a skipped request is let through, later rules do not see it
+		if r.Skip > 0 {
+			r.Skip--
+			return Fault{}, false
+		}
+
+		f := *r
+		if r.Times > 0 {
+			r.Times--
+			if r.Times == 0 {
+				p.rules = append(p.rules[:i], p.rules[i+1:]...)
+			}
+		}
+
+		return f, true
+	}
+
+	return Fault{}, false
+}
+
+// ============
+
+// WithFaultPlan - injects faults from the plan into request handling.
+func WithFaultPlan(p *FaultPlan) Option {
+	return func(s *Server) {
+		s.faults = p
+	}
+}
+
+// SetFaultPlan - replaces the fault plan of a running server. Nil disables faults.
+func (s *Server) SetFaultPlan(p *FaultPlan) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = p
+}
+
+func (s *Server) faultFor(method string) (Fault, bool) {
+	s.mu.Lock()
+	p := s.faults
+	s.mu.Unlock()
+	return p.take(method)
+}
+
+// malformedFrame - a truncated response frame.
+var malformedFrame = []byte(`{"jsonrpc":"2.0","id":`)
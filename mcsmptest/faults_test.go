@@ -0,0 +1,136 @@
+package mcsmptest_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+	"github.com/eterline/go-mc-smp/mcsmptest"
+)
+
+func TestFaultSkipTimes(t *testing.T) {
+	f := mcsmptest.FailWith("minecraft:allowlist", "boom")
+	f.Skip, f.Times = 1, 2
+
+	plan := mcsmptest.NewFaultPlan(f)
+	client := mcsmptest.Start(t, mcsmptest.WithFaultPlan(plan)).TestClient(t)
+
+	want := []bool{false, true, true, false}
+	for i, wantErr := range want {
+		_, err := client.AllowlistGet(context.Background())
+		if gotErr := err != nil; gotErr != wantErr {
+			t.Fatalf("call %d: error = %v, want failure %v", i+1, err, wantErr)
+		}
+	}
+	if plan.Len() != 0 {
+		t.Fatalf("plan has %d faults left, want 0", plan.Len())
+	}
+}
+
+func TestFaultMatchesMethod(t *testing.T) {
+	plan := mcsmptest.NewFaultPlan(mcsmptest.FailWith("minecraft:bans", "boom"))
+	client := mcsmptest.Start(t, mcsmptest.WithFaultPlan(plan)).TestClient(t)
+
+	if _, err := client.AllowlistGet(context.Background()); err != nil {
+		t.Fatalf("AllowlistGet: %v", err)
+	}
+	if _, err := client.BansGet(context.Background()); err == nil {
+		t.Fatal("BansGet succeeded, want the injected error")
+	}
+}
+
+func TestFaultFirstRuleWins(t *testing.T) {
+	plan := mcsmptest.NewFaultPlan(
+		mcsmptest.Fault{Method: "minecraft:allowlist", Times: 1},
+		mcsmptest.FailWith("", "boom"),
+	)
+	client := mcsmptest.Start(t, mcsmptest.WithFaultPlan(plan)).TestClient(t)
+
+	if _, err := client.AllowlistGet(context.Background()); err != nil {
+		t.Fatalf("first AllowlistGet: %v", err)
+	}
+	if _, err := client.AllowlistGet(context.Background()); err == nil {
+		t.Fatal("second AllowlistGet succeeded, want the catch-all error")
+	}
+}
+
+func TestFaultSkipShadowsLaterRules(t *testing.T) {
+	first := mcsmptest.FailWith("minecraft:allowlist", "first")
+	first.Skip, first.Times = 1, 1
+
+	plan := mcsmptest.NewFaultPlan(first, mcsmptest.FailWith("", "second"))
+	client := mcsmptest.Start(t, mcsmptest.WithFaultPlan(plan)).TestClient(t)
+
+	if _, err := client.AllowlistGet(context.Background()); err != nil {
+		t.Fatalf("skipped AllowlistGet: %v", err)
+	}
+
+	_, err := client.AllowlistGet(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "first") {
+		t.Fatalf("second AllowlistGet error = %v, want the first rule", err)
+	}
+
+	_, err = client.AllowlistGet(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "second") {
+		t.Fatalf("third AllowlistGet error = %v, want the second rule", err)
+	}
+}
+
+func TestFaultDelay(t *testing.T) {
+	plan := mcsmptest.NewFaultPlan(mcsmptest.DelayResponse("minecraft:allowlist", time.Second))
+	client := mcsmptest.Start(t, mcsmptest.WithFaultPlan(plan)).TestClient(t, gomcsmp.WithCallTimeout(50*time.Millisecond))
+
+	start := time.Now()
+	if _, err := client.AllowlistGet(context.Background()); err == nil {
+		t.Fatal("AllowlistGet succeeded, want a timeout")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("AllowlistGet took %s, want the call timeout", elapsed)
+	}
+
+	// other methods keep being served meanwhile
+	if _, err := client.BansGet(context.Background()); err != nil {
+		t.Fatalf("BansGet: %v", err)
+	}
+}
+
+func TestFaultMalformed(t *testing.T) {
+	plan := mcsmptest.NewFaultPlan(mcsmptest.MalformedResponse("minecraft:allowlist"))
+	client := mcsmptest.Start(t, mcsmptest.WithFaultPlan(plan)).TestClient(t, gomcsmp.WithCallTimeout(200*time.Millisecond))
+
+	if _, err := client.AllowlistGet(context.Background()); err == nil {
+		t.Fatal("AllowlistGet succeeded, want an error")
+	}
+}
+
+func TestFaultProcess(t *testing.T) {
+	f := mcsmptest.FailWith("minecraft:allowlist/add", "boom")
+	f.Process = true
+
+	srv := mcsmptest.Start(t, mcsmptest.WithFaultPlan(mcsmptest.NewFaultPlan(f)))
+	client := srv.TestClient(t)
+
+	if err := client.AllowlistAdd(context.Background(), gomcsmp.NewPlayer("Steve")); err == nil {
+		t.Fatal("AllowlistAdd succeeded, want the injected error")
+	}
+	if got := srv.State().Allowlist; len(got) != 1 || got[0].Name != "Steve" {
+		t.Fatalf("allowlist = %+v, want Steve added despite the error", got)
+	}
+}
+
+func TestSetFaultPlan(t *testing.T) {
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+
+	srv.SetFaultPlan(mcsmptest.NewFaultPlan(mcsmptest.FailWith("", "boom")))
+	if _, err := client.AllowlistGet(context.Background()); err == nil {
+		t.Fatal("AllowlistGet succeeded, want the injected error")
+	}
+
+	srv.SetFaultPlan(nil)
+	if _, err := client.AllowlistGet(context.Background()); err != nil {
+		t.Fatalf("AllowlistGet after clearing the plan: %v", err)
+	}
+}
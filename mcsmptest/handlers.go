@@ -0,0 +1,550 @@
+package mcsmptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+)
+
+// Error - a JSON-RPC error object returned by the server.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+// JSON-RPC error codes used by the server.
+const (
+	CodeParseError     = -32700
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+func errParse(err error) *Error {
+	return &Error{Code: CodeParseError, Message: "Parse error", Data: err.Error()}
+}
+
+func errInvalidParams(format string, a ...any) *Error {
+	return &Error{Code: CodeInvalidParams, Message: "Invalid params", Data: fmt.Sprintf(format, a...)}
+}
+
+// ============
+
+// call - the context of one handled request.
+type call struct {
+	st     *State
+	params []json.RawMessage
+	events []event
+}
+
+func (c *call) notify(method string, payload any) {
+	c.events = append(c.events, event{method: method, payload: payload})
+}
+
+type handlerFunc func(c *call) (any, *Error)
+
+func param[T any](c *call) (T, *Error) {
+	var v T
+	if len(c.params) == 0 {
+		return v, errInvalidParams("missing params")
+	}
+	if err := json.Unmarshal(c.params[0], &v); err != nil {
+		return v, errInvalidParams("%v", err)
+	}
+	return v, nil
+}
+
+func methodName(root string, path ...string) string {
+	return "minecraft:" + strings.Join(append([]string{root}, path...), "/")
+}
+
+func notificationName(domain, event string) string {
+	return methodName("notification", domain, event)
+}
+
+// ============
+
+func (s *Server) handle(req request) (any, []event, *Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := handlers[req.Method]
+	if !ok {
+		return nil, nil, &Error{Code: CodeMethodNotFound, Message: "Method not found", Data: req.Method}
+	}
+
+	c := &call{st: &s.state, params: req.Params}
+	result, err := h(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, c.events, nil
+}
+
+var handlers = buildHandlers()
+
+// buildHandlers - the served methods, written out independently of the
+// gomcsmp catalogue so that a wrong catalogue name fails against the fake.
+func buildHandlers() map[string]handlerFunc {
+	h := map[string]handlerFunc{
+		"minecraft:players":      playersGet,
+		"minecraft:players/kick": playersKick,
+
+		"minecraft:allowlist":        allowlistGet,
+		"minecraft:allowlist/set":    allowlistSet,
+		"minecraft:allowlist/add":    allowlistAdd,
+		"minecraft:allowlist/remove": allowlistRemove,
+		"minecraft:allowlist/clear":  allowlistClear,
+
+		"minecraft:bans":        bansGet,
+		"minecraft:bans/set":    bansSet,
+		"minecraft:bans/add":    bansAdd,
+		"minecraft:bans/remove": bansRemove,
+		"minecraft:bans/clear":  bansClear,
+
+		"minecraft:ip_bans":        ipBansGet,
+		"minecraft:ip_bans/set":    ipBansSet,
+		"minecraft:ip_bans/add":    ipBansAdd,
+		"minecraft:ip_bans/remove": ipBansRemove,
+		"minecraft:ip_bans/clear":  ipBansClear,
+
+		"minecraft:operators":        operatorsGet,
+		"minecraft:operators/set":    operatorsSet,
+		"minecraft:operators/add":    operatorsAdd,
+		"minecraft:operators/remove": operatorsRemove,
+		"minecraft:operators/clear":  operatorsClear,
+
+		"minecraft:gamerules":        gamerulesGet,
+		"minecraft:gamerules/update": gamerulesUpdate,
+
+		"minecraft:server/status":         serverStatus,
+		"minecraft:server/save":           serverSave,
+		"minecraft:server/stop":           serverStop,
+		"minecraft:server/system_message": serverSystemMessage,
+	}
+
+	// every default setting has a getter and a setter taking its wire type
+	for key, v := range DefaultState().Settings {
+		h["minecraft:serversettings/"+key] = settingGet(key)
+		h["minecraft:serversettings/"+key+"/set"] = settingSet(key, reflect.TypeOf(v))
+	}
+
+	return h
+}
+
+// ============
+
+func orEmpty[T any](list []T) []T {
+	if list == nil {
+		return []T{}
+	}
+	return list
+}
+
+func playersGet(c *call) (any, *Error) {
+	return orEmpty(c.st.Online), nil
+}
+
+func playersKick(c *call) (any, *Error) {
+	kicks, err := param[[]gomcsmp.KickPlayer](c)
+	if err != nil {
+		return nil, err
+	}
+
+	kicked := []gomcsmp.Player{}
+	for _, k := range kicks {
+		var removed []gomcsmp.Player
+		c.st.Online, removed = remove(c.st.Online, k.Player, samePlayer)
+		for _, p := range removed {
+			kicked = append(kicked, p)
+			c.notify(notificationName("players", "left"), p)
+		}
+	}
+
+	return kicked, nil
+}
+
+// ============
+
+func allowlistGet(c *call) (any, *Error) {
+	return orEmpty(c.st.Allowlist), nil
+}
+
+func allowlistSet(c *call) (any, *Error) {
+	players, err := param[[]gomcsmp.Player](c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range c.st.Allowlist {
+		if indexPlayer(players, p) < 0 {
+			c.notify(notificationName("allowlist", "removed"), p)
+		}
+	}
+	for _, p := range players {
+		if indexPlayer(c.st.Allowlist, p) < 0 {
+			c.notify(notificationName("allowlist", "added"), p)
+		}
+	}
+
+	c.st.Allowlist = players
+	return orEmpty(c.st.Allowlist), nil
+}
+
+func allowlistAdd(c *call) (any, *Error) {
+	players, err := param[[]gomcsmp.Player](c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range players {
+		if indexPlayer(c.st.Allowlist, p) >= 0 {
+			continue
+		}
+		c.st.Allowlist = append(c.st.Allowlist, p)
+		c.notify(notificationName("allowlist", "added"), p)
+	}
+
+	return orEmpty(c.st.Allowlist), nil
+}
+
+func allowlistRemove(c *call) (any, *Error) {
+	players, err := param[[]gomcsmp.Player](c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range players {
+		var removed []gomcsmp.Player
+		c.st.Allowlist, removed = remove(c.st.Allowlist, p, samePlayer)
+		for _, r := range removed {
+			c.notify(notificationName("allowlist", "removed"), r)
+		}
+	}
+
+	return orEmpty(c.st.Allowlist), nil
+}
+
+func allowlistClear(c *call) (any, *Error) {
+	for _, p := range c.st.Allowlist {
+		c.notify(notificationName("allowlist", "removed"), p)
+	}
+	c.st.Allowlist = nil
+	return orEmpty(c.st.Allowlist), nil
+}
+
+// ============
+
+func sameUserBan(a, b gomcsmp.UserBan) bool {
+	return samePlayer(a.Player, b.Player)
+}
+
+func bansGet(c *call) (any, *Error) {
+	return orEmpty(c.st.Bans), nil
+}
+
+func bansSet(c *call) (any, *Error) {
+	bans, err := param[[]gomcsmp.UserBan](c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range c.st.Bans {
+		c.notify(notificationName("bans", "removed"), b)
+	}
+	for _, b := range bans {
+		c.notify(notificationName("bans", "added"), b)
+	}
+
+	c.st.Bans = bans
+	return orEmpty(c.st.Bans), nil
+}
+
+func bansAdd(c *call) (any, *Error) {
+	bans, err := param[[]gomcsmp.UserBan](c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range bans {
+		c.st.Bans = upsert(c.st.Bans, b, sameUserBan)
+		c.notify(notificationName("bans", "added"), b)
+	}
+
+	return orEmpty(c.st.Bans), nil
+}
+
+func bansRemove(c *call) (any, *Error) {
+	players, err := param[[]gomcsmp.Player](c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range players {
+		var removed []gomcsmp.UserBan
+		c.st.Bans, removed = remove(c.st.Bans, gomcsmp.UserBan{Player: p}, sameUserBan)
+		for _, b := range removed {
+			c.notify(notificationName("bans", "removed"), b)
+		}
+	}
+
+	return orEmpty(c.st.Bans), nil
+}
+
+func bansClear(c *call) (any, *Error) {
+	for _, b := range c.st.Bans {
+		c.notify(notificationName("bans", "removed"), b)
+	}
+	c.st.Bans = nil
+	return orEmpty(c.st.Bans), nil
+}
+
+// ============
+
+func sameIPBan(a, b gomcsmp.IPBan) bool {
+	return a.IP == b.IP
+}
+
+func incomingIPBan(b gomcsmp.IPBan) gomcsmp.IncomingIPBan {
+	return gomcsmp.IncomingIPBan{
+		Reason:  b.Reason,
+		Expires: b.Expires,
+		IP:      b.IP,
+		Source:  b.Source,
+	}
+}
+
+func ipBansGet(c *call) (any, *Error) {
+	return orEmpty(c.st.IPBans), nil
+}
+
+func ipBansSet(c *call) (any, *Error) {
+	bans, err := param[[]gomcsmp.IPBan](c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range c.st.IPBans {
+		c.notify(notificationName("ip_bans", "removed"), incomingIPBan(b))
+	}
+	for _, b := range bans {
+		c.notify(notificationName("ip_bans", "added"), incomingIPBan(b))
+	}
+
+	c.st.IPBans = bans
+	return orEmpty(c.st.IPBans), nil
+}
+
+func ipBansAdd(c *call) (any, *Error) {
+	bans, err := param[[]gomcsmp.IPBan](c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range bans {
+		if _, err := b.Addr(); err != nil {
+			return nil, errInvalidParams("%v", err)
+		}
+		c.st.IPBans = upsert(c.st.IPBans, b, sameIPBan)
+		c.notify(notificationName("ip_bans", "added"), incomingIPBan(b))
+	}
+
+	return orEmpty(c.st.IPBans), nil
+}
+
+func ipBansRemove(c *call) (any, *Error) {
+	bans, err := param[[]gomcsmp.IPBan](c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range bans {
+		var removed []gomcsmp.IPBan
+		c.st.IPBans, removed = remove(c.st.IPBans, b, sameIPBan)
+		for _, r := range removed {
+			c.notify(notificationName("ip_bans", "removed"), incomingIPBan(r))
+		}
+	}
+
+	return orEmpty(c.st.IPBans), nil
+}
+
+func ipBansClear(c *call) (any, *Error) {
+	for _, b := range c.st.IPBans {
+		c.notify(notificationName("ip_bans", "removed"), incomingIPBan(b))
+	}
+	c.st.IPBans = nil
+	return orEmpty(c.st.IPBans), nil
+}
+
+// ============
+
+func sameOperator(a, b gomcsmp.Operator) bool {
+	return samePlayer(a.Player, b.Player)
+}
+
+func operatorsGet(c *call) (any, *Error) {
+	return orEmpty(c.st.Operators), nil
+}
+
+func operatorsSet(c *call) (any, *Error) {
+	ops, err := param[[]gomcsmp.Operator](c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range c.st.Operators {
+		c.notify(notificationName("operators", "removed"), op)
+	}
+	for _, op := range ops {
+		c.notify(notificationName("operators", "added"), op)
+	}
+
+	c.st.Operators = ops
+	return orEmpty(c.st.Operators), nil
+}
+
+func operatorsAdd(c *call) (any, *Error) {
+	ops, err := param[[]gomcsmp.Operator](c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range ops {
+		c.st.Operators = upsert(c.st.Operators, op, sameOperator)
+		c.notify(notificationName("operators", "added"), op)
+	}
+
+	return orEmpty(c.st.Operators), nil
+}
+
+func operatorsRemove(c *call) (any, *Error) {
+	players, err := param[[]gomcsmp.Player](c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range players {
+		var removed []gomcsmp.Operator
+		c.st.Operators, removed = remove(c.st.Operators, gomcsmp.Operator{Player: p}, sameOperator)
+		for _, op := range removed {
+			c.notify(notificationName("operators", "removed"), op)
+		}
+	}
+
+	return orEmpty(c.st.Operators), nil
+}
+
+func operatorsClear(c *call) (any, *Error) {
+	for _, op := range c.st.Operators {
+		c.notify(notificationName("operators", "removed"), op)
+	}
+	c.st.Operators = nil
+	return orEmpty(c.st.Operators), nil
+}
+
+// ============
+
+func gamerulesGet(c *call) (any, *Error) {
+	return orEmpty(c.st.GameRules), nil
+}
+
+func gamerulesUpdate(c *call) (any, *Error) {
+	rule, err := param[gomcsmp.GameRule](c)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, gr := range c.st.GameRules {
+		if gr.Key != rule.Key {
+			continue
+		}
+
+		switch gr.Type {
+		case gomcsmp.BooleanGameRule:
+			if rule.Value != "true" && rule.Value != "false" {
+				return nil, errInvalidParams("gamerule '%s' expects boolean, got '%s'", rule.Key, rule.Value)
+			}
+		case gomcsmp.IntegerGameRule:
+			if _, err := strconv.Atoi(rule.Value); err != nil {
+				return nil, errInvalidParams("gamerule '%s' expects integer, got '%s'", rule.Key, rule.Value)
+			}
+		}
+
+		gr.Value = rule.Value
+		c.st.GameRules[i] = gr
+		c.notify(notificationName("gamerules", "updated"), gr)
+		return gr, nil
+	}
+
+	return nil, errInvalidParams("unknown gamerule '%s'", rule.Key)
+}
+
+// ============
+
+func serverStatus(c *call) (any, *Error) {
+	return c.st.status(), nil
+}
+
+func serverSave(c *call) (any, *Error) {
+	if _, err := param[bool](c); err != nil {
+		return nil, err
+	}
+	c.notify(notificationName("server", "saving"), nil)
+	c.notify(notificationName("server", "saved"), nil)
+	return true, nil
+}
+
+func serverStop(c *call) (any, *Error) {
+	c.st.Started = false
+	c.notify(notificationName("server", "stopping"), nil)
+	return true, nil
+}
+
+func serverSystemMessage(c *call) (any, *Error) {
+	msg, err := param[gomcsmp.SystemMessage](c)
+	if err != nil {
+		return nil, err
+	}
+	c.st.Messages = append(c.st.Messages, msg)
+	return true, nil
+}
+
+// ============
+
+func settingGet(key string) handlerFunc {
+	return func(c *call) (any, *Error) {
+		v, ok := c.st.Settings[key]
+		if !ok {
+			return nil, &Error{Code: CodeInternalError, Message: "Internal error", Data: "setting '" + key + "' is not set"}
+		}
+		return v, nil
+	}
+}
+
+func settingSet(key string, typ reflect.Type) handlerFunc {
+	return func(c *call) (any, *Error) {
+		if len(c.params) == 0 {
+			return nil, errInvalidParams("missing params")
+		}
+
+		ptr := reflect.New(typ)
+		if err := json.Unmarshal(c.params[0], ptr.Interface()); err != nil {
+			return nil, errInvalidParams("%v", err)
+		}
+
+		v := ptr.Elem().Interface()
+		c.st.Settings[key] = v
+		return v, nil
+	}
+}
@@ -0,0 +1,23 @@
+package mcsmptest
+
+import (
+	"testing"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+)
+
+func TestHandlersMatchCatalogue(t *testing.T) {
+	catalogued := map[string]bool{}
+	for _, m := range gomcsmp.Methods() {
+		catalogued[m.Name] = true
+		if _, ok := handlers[m.Name]; !ok {
+			t.Errorf("catalogue method %s is not served by the fake", m.Name)
+		}
+	}
+
+	for name := range handlers {
+		if !catalogued[name] {
+			t.Errorf("fake serves %s which is missing from the catalogue", name)
+		}
+	}
+}
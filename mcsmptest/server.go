@@ -0,0 +1,431 @@
+// Package mcsmptest provides an in-memory fake of the Minecraft server management endpoint.
+//
+// The Server speaks the same JSON-RPC over WebSocket protocol as the real server,
+// keeps players, allowlist, operators, bans, gamerules and settings in memory,
+// and lets tests trigger server notifications, so code built on gomcsmp.RPCClient
+// can be tested offline and deterministically.
+package mcsmptest
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+	"github.com/gorilla/websocket"
+)
+
+// DefaultToken - the bearer token accepted by a Server created without WithToken.
+const DefaultToken = "mcsmptest-token"
+
+type Option func(*Server)
+
+// WithToken - sets the bearer token required from clients.
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithState - sets the initial server state instead of DefaultState.
+func WithState(st State) Option {
+	return func(s *Server) {
+		s.state = st.clone()
+	}
+}
+
+// ============
+
+// Server - a fake management server backed by httptest.
+type Server struct {
+	http     *httptest.Server
+	upgrader websocket.Upgrader
+	token    string
+
+	mu     sync.Mutex
+	state  State
+	calls  []string
+	conns  map[*conn]struct{}
+	faults *FaultPlan
+}
+
+// NewServer - starts a new fake server listening on a local port.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		token: DefaultToken,
+		state: DefaultState(),
+		conns: make(map[*conn]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.state.Settings == nil {
+		s.state.Settings = DefaultState().Settings
+	}
+
+	s.http = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL - returns the WebSocket URL of the server.
+func (s *Server) URL() string {
+	return "ws" + s.http.URL[len("http"):]
+}
+
+// Host - returns the host the server listens on.
+func (s *Server) Host() string {
+	host, _, _ := net.SplitHostPort(s.http.Listener.Addr().String())
+	return host
+}
+
+// Port - returns the port the server listens on.
+func (s *Server) Port() uint16 {
+	_, port, _ := net.SplitHostPort(s.http.Listener.Addr().String())
+	p, _ := strconv.ParseUint(port, 10, 16)
+	return uint16(p)
+}
+
+// Token - returns the bearer token accepted by the server.
+func (s *Server) Token() string {
+	return s.token
+}
+
+// Client - dials the server with a new gomcsmp.RPCClient.
+func (s *Server) Client(opts ...gomcsmp.ClientOption) (*gomcsmp.RPCClient, error) {
+	return gomcsmp.NewClient(s.Host(), s.Port(), s.token, opts...)
+}
+
+// Start - starts a server closed when the test ends.
+func Start(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+
+	s := NewServer(opts...)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// TestClient - dials the server with a client closed when the test ends.
+// A failed dial fails the test.
+func (s *Server) TestClient(t testing.TB, opts ...gomcsmp.ClientOption) *gomcsmp.RPCClient {
+	t.Helper()
+
+	client, err := s.Client(opts...)
+	if err != nil {
+		t.Fatalf("dial fake server: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// Close - disconnects all clients and stops the server.
+func (s *Server) Close() {
+	s.mu.Lock()
+	for c := range s.conns {
+		c.close()
+	}
+	s.mu.Unlock()
+
+	s.http.Close()
+}
+
+// ============
+
+// State - returns a copy of the current server state.
+func (s *Server) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.clone()
+}
+
+// Update - modifies the server state under lock. No notifications are sent.
+func (s *Server) Update(fn func(st *State)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.state)
+}
+
+// Calls - returns the names of all methods called so far, in order.
+func (s *Server) Calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+// CallCount - returns how many times the method was called so far.
+func (s *Server) CallCount(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, m := range s.calls {
+		if m == method {
+			n++
+		}
+	}
+	return n
+}
+
+// ============
+
+// Notify - sends a notification with the given payload to all connected clients.
+// A nil payload sends a notification without params.
+func (s *Server) Notify(method string, payload any) {
+	s.broadcast([]event{{method: method, payload: payload}})
+}
+
+// Join - puts the player online and sends players/joined.
+func (s *Server) Join(p gomcsmp.Player) {
+	s.mu.Lock()
+	s.state.Online = upsert(s.state.Online, p, samePlayer)
+	s.mu.Unlock()
+
+	s.Notify(notificationName("players", "joined"), p)
+}
+
+// Leave - takes the player offline and sends players/left.
+func (s *Server) Leave(name string) {
+	s.mu.Lock()
+	rest, removed := remove(s.state.Online, gomcsmp.NewPlayer(name), samePlayer)
+	s.state.Online = rest
+	s.mu.Unlock()
+
+	for _, p := range removed {
+		s.Notify(notificationName("players", "left"), p)
+	}
+}
+
+// Started - marks the server started and sends server/started.
+func (s *Server) Started() {
+	s.Update(func(st *State) { st.Started = true })
+	s.Notify(notificationName("server", "started"), nil)
+}
+
+// Stopping - marks the server stopped and sends server/stopping.
+func (s *Server) Stopping() {
+	s.Update(func(st *State) { st.Started = false })
+	s.Notify(notificationName("server", "stopping"), nil)
+}
+
+// Saving - sends server/saving.
+func (s *Server) Saving() {
+	s.Notify(notificationName("server", "saving"), nil)
+}
+
+// Saved - sends server/saved.
+func (s *Server) Saved() {
+	s.Notify(notificationName("server", "saved"), nil)
+}
+
+// Heartbeat - sends server/status with the current status.
+func (s *Server) Heartbeat() {
+	s.mu.Lock()
+	status := s.state.status()
+	s.mu.Unlock()
+
+	s.Notify(notificationName("server", "status"), status)
+}
+
+// ============
+
+type event struct {
+	method  string
+	payload any
+}
+
+func (s *Server) broadcast(events []event) {
+	if len(events) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	conns := make([]*conn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.mu.Unlock()
+
+	for _, e := range events {
+		n := notification{JSONRPC: "2.0", Method: e.method}
+		if e.payload != nil {
+			b, err := json.Marshal(e.payload)
+			if err != nil {
+				continue
+			}
+			n.Params = []json.RawMessage{b}
+		}
+
+		for _, c := range conns {
+			_ = c.writeJSON(n)
+		}
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	c := &conn{ws: ws}
+
+	s.mu.Lock()
+	s.conns[c] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		c.close()
+	}()
+
+	for {
+		_, msg, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var req request
+		if err := json.Unmarshal(msg, &req); err != nil {
+			_ = c.writeJSON(response{JSONRPC: "2.0", Error: errParse(err)})
+			continue
+		}
+
+		s.serveRequest(c, req)
+	}
+}
+
+func (s *Server) serveRequest(c *conn, req request) {
+	s.mu.Lock()
+	s.calls = append(s.calls, req.Method)
+	s.mu.Unlock()
+
+	fault, faulty := s.faultFor(req.Method)
+	if faulty && fault.Disconnect {
+		c.close()
+		return
+	}
+
+	var (
+		result any
+		events []event
+		rpcErr *Error
+	)
+
+	if !faulty || fault.Error == nil || fault.Process {
+		result, events, rpcErr = s.handle(req)
+	}
+	if faulty && fault.Error != nil {
+		rpcErr = fault.Error
+	}
+
+	resp := response{JSONRPC: "2.0", ID: req.ID}
+	if rpcErr != nil {
+		resp.Error = rpcErr
+	} else {
+		resp.Result = result
+	}
+
+	send := func() {
+		switch {
+		case faulty && fault.Drop:
+		case faulty && fault.Malformed:
+			_ = c.writeRaw(malformedFrame)
+		case faulty && fault.Reorder:
+			c.hold(resp)
+		default:
+			_ = c.writeResponse(resp)
+		}
+		s.broadcast(events)
+	}
+
+	if faulty && fault.Delay > 0 {
+		time.AfterFunc(fault.Delay, send)
+		return
+	}
+
+	send()
+}
+
+// ============
+
+type conn struct {
+	mu   sync.Mutex
+	ws   *websocket.Conn
+	held []response
+}
+
+func (c *conn) writeJSON(v any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ws.WriteJSON(v)
+}
+
+func (c *conn) writeRaw(b []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ws.WriteMessage(websocket.TextMessage, b)
+}
+
+// writeResponse - sends the response followed by any held back ones.
+func (c *conn) writeResponse(resp response) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.ws.WriteJSON(resp); err != nil {
+		return err
+	}
+
+	held := c.held
+	c.held = nil
+	for _, h := range held {
+		if err := c.ws.WriteJSON(h); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *conn) hold(resp response) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.held = append(c.held, resp)
+}
+
+func (c *conn) close() {
+	_ = c.ws.Close()
+}
+
+// ============
+
+type request struct {
+	ID     int               `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id,omitempty"`
+	Result  any    `json:"result,omitempty"`
+	Error   *Error `json:"error,omitempty"`
+}
+
+type notification struct {
+	JSONRPC string            `json:"jsonrpc"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params,omitempty"`
+}
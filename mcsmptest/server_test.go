@@ -0,0 +1,115 @@
+package mcsmptest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+	"github.com/eterline/go-mc-smp/mcsmptest"
+)
+
+func TestServerState(t *testing.T) {
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+	ctx := context.Background()
+
+	if err := client.AllowlistAdd(ctx, gomcsmp.NewPlayer("Steve"), gomcsmp.NewPlayer("Alex")); err != nil {
+		t.Fatalf("AllowlistAdd: %v", err)
+	}
+	if err := client.AllowlistRemove(ctx, gomcsmp.NewPlayer("steve")); err != nil {
+		t.Fatalf("AllowlistRemove: %v", err)
+	}
+
+	got := srv.State().Allowlist
+	if len(got) != 1 || got[0].Name != "Alex" {
+		t.Fatalf("allowlist = %+v, want only Alex", got)
+	}
+
+	allowlist, err := client.AllowlistGet(ctx)
+	if err != nil {
+		t.Fatalf("AllowlistGet: %v", err)
+	}
+	if allowlist.Len() != 1 || !allowlist.ContainsPlayer(gomcsmp.NewPlayer("Alex")) {
+		t.Fatalf("AllowlistGet = %v, want only Alex", allowlist.Players())
+	}
+}
+
+func TestServerUpdate(t *testing.T) {
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+
+	srv.Update(func(st *mcsmptest.State) { st.Started = false })
+
+	status, err := client.ServerStatus(context.Background())
+	if err != nil {
+		t.Fatalf("ServerStatus: %v", err)
+	}
+	if status.Started {
+		t.Fatal("server reported started after Update stopped it")
+	}
+}
+
+func TestServerCalls(t *testing.T) {
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+	ctx := context.Background()
+
+	client.AllowlistGet(ctx)
+	client.BansGet(ctx)
+	client.AllowlistGet(ctx)
+
+	want := []string{"minecraft:allowlist", "minecraft:bans", "minecraft:allowlist"}
+	got := srv.Calls()
+	if len(got) != len(want) {
+		t.Fatalf("calls = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("calls = %v, want %v", got, want)
+		}
+	}
+	if n := srv.CallCount("minecraft:allowlist"); n != 2 {
+		t.Fatalf("CallCount = %d, want 2", n)
+	}
+}
+
+func TestServerNotifications(t *testing.T) {
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	joined := client.NotifyPlayersJoined(ctx)
+	srv.Join(gomcsmp.NewPlayer("Steve"))
+
+	select {
+	case p := <-joined:
+		if p.Name != "Steve" {
+			t.Fatalf("joined = %+v, want Steve", p)
+		}
+	case <-ctx.Done():
+		t.Fatal("no players/joined notification")
+	}
+
+	online, err := client.PlayersGet(ctx)
+	if err != nil {
+		t.Fatalf("PlayersGet: %v", err)
+	}
+	if online.Len() != 1 {
+		t.Fatalf("online = %d players, want 1", online.Len())
+	}
+}
+
+func TestServerToken(t *testing.T) {
+	srv := mcsmptest.Start(t, mcsmptest.WithToken("secret"))
+
+	if _, err := gomcsmp.NewClient(srv.Host(), srv.Port(), "wrong"); err == nil {
+		t.Fatal("dial with a wrong token succeeded")
+	}
+	if srv.Token() != "secret" {
+		t.Fatalf("Token = %q, want secret", srv.Token())
+	}
+	srv.TestClient(t)
+}
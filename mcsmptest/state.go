@@ -0,0 +1,130 @@
+package mcsmptest
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+)
+
+// State - the complete state held by the fake management server.
+// Settings are keyed by serversettings path (e.g. "max_players")
+// and hold wire values: bool, string or int.
+type State struct {
+	Started   bool
+	Version   gomcsmp.Version
+	Online    []gomcsmp.Player
+	Allowlist []gomcsmp.Player
+	Operators []gomcsmp.Operator
+	Bans      []gomcsmp.UserBan
+	IPBans    []gomcsmp.IPBan
+	GameRules []gomcsmp.GameRule
+	Settings  map[string]any
+	Messages  []gomcsmp.SystemMessage
+}
+
+// DefaultState - returns the state of a freshly started vanilla server with nobody online.
+// Game rules are the catalogue defaults of the server version.
+func DefaultState() State {
+	version := gomcsmp.Version{Protocol: 773, Name: gomcsmp.ProtocolMinVersion}
+
+	return State{
+		Started:   true,
+		Version:   version,
+		GameRules: defaultGameRules(version),
+		Settings: map[string]any{
+			"autosave":                       true,
+			"difficulty":                     "easy",
+			"enforce_allowlist":              false,
+			"use_allowlist":                  false,
+			"max_players":                    20,
+			"pause_when_empty_seconds":       60,
+			"player_idle_timeout":            0,
+			"allow_flight":                   false,
+			"motd":                           "A Minecraft Server",
+			"spawn_protection_radius":        16,
+			"force_game_mode":                false,
+			"game_mode":                      "survival",
+			"view_distance":                  10,
+			"simulation_distance":            10,
+			"accept_transfers":               false,
+			"status_heartbeat_interval":      0,
+			"operator_user_permission_level": 4,
+			"hide_online_players":            false,
+			"status_replies":                 true,
+			"entity_broadcast_range":         100,
+		},
+	}
+}
+
+// clone - returns a copy of the state which shares no slices or maps with the original.
+func (st State) clone() State {
+	st.Online = slices.Clone(st.Online)
+	st.Allowlist = slices.Clone(st.Allowlist)
+	st.Operators = slices.Clone(st.Operators)
+	st.Bans = slices.Clone(st.Bans)
+	st.IPBans = slices.Clone(st.IPBans)
+	st.GameRules = slices.Clone(st.GameRules)
+	st.Settings = maps.Clone(st.Settings)
+	st.Messages = slices.Clone(st.Messages)
+	return st
+}
+
+func (st *State) status() gomcsmp.ServerState {
+	return gomcsmp.ServerState{
+		Players: gomcsmp.NewPlayerRegistry(st.Online),
+		Started: st.Started,
+		Version: st.Version,
+	}
+}
+
+func defaultGameRules(v gomcsmp.Version) []gomcsmp.GameRule {
+	rules := []gomcsmp.GameRule{}
+	for _, info := range gomcsmp.GameRuleCatalogue() {
+		if info.SupportedBy(v) {
+			rules = append(rules, info.DefaultRule())
+		}
+	}
+	return rules
+}
+
+// ============
+
+// samePlayer - matches players by UUID when both have one, else by case-insensitive name.
+func samePlayer(a, b gomcsmp.Player) bool {
+	if a.ID != nil && b.ID != nil {
+		return *a.ID == *b.ID
+	}
+	return strings.EqualFold(a.Name, b.Name)
+}
+
+func indexPlayer(list []gomcsmp.Player, p gomcsmp.Player) int {
+	return slices.IndexFunc(list, func(e gomcsmp.Player) bool {
+		return samePlayer(e, p)
+	})
+}
+
+// upsert - replaces the element matching v or appends it.
+func upsert[T any](list []T, v T, match func(a, b T) bool) []T {
+	for i, e := range list {
+		if match(e, v) {
+			list[i] = v
+			return list
+		}
+	}
+	return append(list, v)
+}
+
+// remove - deletes the elements matching v and returns them.
+func remove[T any](list []T, v T, match func(a, b T) bool) (rest []T, removed []T) {
+	rest = list[:0]
+	for _, e := range list {
+		if match(e, v) {
+			removed = append(removed, e)
+			continue
+		}
+		rest = append(rest, e)
+	}
+	return rest, removed
+}
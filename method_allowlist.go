@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/eterline/go-mc-smp/internal/jsonrpc"
-	"github.com/eterline/go-mc-smp/internal/usage"
 )
 
 // Endpoints are accessible at minecraft:allowlist
@@ -20,8 +19,7 @@ import (
 
 // AllowlistGet - Get the allowlist
 func (rpc *RPCClient) AllowlistGet(ctx context.Context) (*PlayerRegistry, error) {
-	method := usage.NewMethod("allowlist").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
+	r, err := rpc.call(ctx, methodAllowlist.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -36,50 +34,120 @@ func (rpc *RPCClient) AllowlistGet(ctx context.Context) (*PlayerRegistry, error)
 
 // AllowlistAdd - Add players to the allowlist
 func (rpc *RPCClient) AllowlistAdd(ctx context.Context, p ...Player) error {
-	method := usage.NewMethod("allowlist").Add("add").String()
-	r, err := rpc.core.CallWithContext(ctx, method, p)
+	p, err := rpc.allowlistAddParams(ctx, p)
 	if err != nil {
 		return err
 	}
 
-	return r.Err()
+	return rpc.send(ctx, methodAllowlistAdd.Name, p)
+}
+
+// AllowlistAddWithResult - Add players to the allowlist, returning the resulting list
+func (rpc *RPCClient) AllowlistAddWithResult(ctx context.Context, p ...Player) (*PlayerRegistry, error) {
+	p, err := rpc.allowlistAddParams(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := rpc.call(ctx, methodAllowlistAdd.Name, p)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := jsonrpc.DecodeRPCResult[PlayerRegistry](r)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// allowlistAddParams - resolves and validates the players to add.
+func (rpc *RPCClient) allowlistAddParams(ctx context.Context, p []Player) ([]Player, error) {
+	p, err := rpc.resolvePlayers(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePlayers(p...); err != nil {
+		return nil, err
+	}
+	return p, nil
 }
 
 // AllowlistRemove - Remove players from allowlist
 func (rpc *RPCClient) AllowlistRemove(ctx context.Context, p ...Player) error {
-	method := usage.NewMethod("allowlist").Add("remove").String()
-	r, err := rpc.core.CallWithContext(ctx, method, p)
+	return rpc.send(ctx, methodAllowlistRemove.Name, p)
+}
+
+// AllowlistRemoveWithResult - Remove players from allowlist, returning the resulting list
+func (rpc *RPCClient) AllowlistRemoveWithResult(ctx context.Context, p ...Player) (*PlayerRegistry, error) {
+	r, err := rpc.call(ctx, methodAllowlistRemove.Name, p)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	data, err := jsonrpc.DecodeRPCResult[PlayerRegistry](r)
+	if err != nil {
+		return nil, err
 	}
 
-	return r.Err()
+	return data, nil
 }
 
 // AllowlistClear - Clear all players in allowlist
 func (rpc *RPCClient) AllowlistClear(ctx context.Context) error {
-	method := usage.NewMethod("allowlist").Add("clear").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
+	return rpc.send(ctx, methodAllowlistClear.Name)
+}
+
+// AllowlistClearWithResult - Clear all players in allowlist, returning the resulting list
+func (rpc *RPCClient) AllowlistClearWithResult(ctx context.Context) (*PlayerRegistry, error) {
+	r, err := rpc.call(ctx, methodAllowlistClear.Name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return r.Err()
+	data, err := jsonrpc.DecodeRPCResult[PlayerRegistry](r)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
 }
 
 // AllowlistSet - Set the allowlist to the provided list of players
 func (rpc *RPCClient) AllowlistSet(ctx context.Context, p ...Player) error {
-	for _, pl := range p {
-		if pl.ID == nil {
-			return fmt.Errorf("player '%s' must have certain UUID", pl.Name)
-		}
+	if err := checkAllowlistSet(p); err != nil {
+		return err
+	}
+
+	return rpc.send(ctx, methodAllowlistSet.Name, p)
+}
+
+// AllowlistSetWithResult - Set the allowlist to the provided list of players, returning the resulting list
+func (rpc *RPCClient) AllowlistSetWithResult(ctx context.Context, p ...Player) (*PlayerRegistry, error) {
+	if err := checkAllowlistSet(p); err != nil {
+		return nil, err
 	}
 
-	method := usage.NewMethod("allowlist").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, p)
+	r, err := rpc.call(ctx, methodAllowlistSet.Name, p)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	data, err := jsonrpc.DecodeRPCResult[PlayerRegistry](r)
+	if err != nil {
+		return nil, err
 	}
 
-	return r.Err()
+	return data, nil
+}
+
+// checkAllowlistSet - requires a UUID for every player, names alone are not accepted by /set.
+func checkAllowlistSet(p []Player) error {
+	for _, pl := range p {
+		if pl.ID == nil {
+			return fmt.Errorf("player '%s' must have certain UUID", pl.Name)
+		}
+	}
+	return nil
 }
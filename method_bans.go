@@ -2,9 +2,9 @@ package gomcsmp
 
 import (
 	"context"
+	"slices"
 
 	"github.com/eterline/go-mc-smp/internal/jsonrpc"
-	"github.com/eterline/go-mc-smp/internal/usage"
 )
 
 // Endpoints are accessible at minecraft:bans
@@ -19,8 +19,7 @@ import (
 
 // BansGet - Get the ban list
 func (rpc *RPCClient) BansGet(ctx context.Context) ([]UserBan, error) {
-	method := usage.NewMethod("bans").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
+	r, err := rpc.call(ctx, methodBans.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -35,44 +34,106 @@ func (rpc *RPCClient) BansGet(ctx context.Context) ([]UserBan, error) {
 
 // BansSet - Set the banlist
 func (rpc *RPCClient) BansSet(ctx context.Context, ban ...UserBan) error {
-	method := usage.NewMethod("bans").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, ban)
+	return rpc.send(ctx, methodBansSet.Name, ban)
+}
+
+// BansSetWithResult - Set the banlist, returning the resulting list
+func (rpc *RPCClient) BansSetWithResult(ctx context.Context, ban ...UserBan) ([]UserBan, error) {
+	r, err := rpc.call(ctx, methodBansSet.Name, ban)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return r.Err()
+	data, err := jsonrpc.DecodeRPCResult[[]UserBan](r)
+	if err != nil {
+		return nil, err
+	}
+
+	return *data, nil
 }
 
 // BansAdd - Add players to the ban list
 func (rpc *RPCClient) BansAdd(ctx context.Context, ban ...UserBan) error {
-	method := usage.NewMethod("bans").Add("add").String()
-	r, err := rpc.core.CallWithContext(ctx, method, ban)
+	ban, err := rpc.bansAddParams(ctx, ban)
 	if err != nil {
 		return err
 	}
 
-	return r.Err()
+	return rpc.send(ctx, methodBansAdd.Name, ban)
+}
+
+// BansAddWithResult - Add players to the ban list, returning the resulting list
+func (rpc *RPCClient) BansAddWithResult(ctx context.Context, ban ...UserBan) ([]UserBan, error) {
+	ban, err := rpc.bansAddParams(ctx, ban)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := rpc.call(ctx, methodBansAdd.Name, ban)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := jsonrpc.DecodeRPCResult[[]UserBan](r)
+	if err != nil {
+		return nil, err
+	}
+
+	return *data, nil
+}
+
+// bansAddParams - resolves and validates the players of the entries to add.
+func (rpc *RPCClient) bansAddParams(ctx context.Context, ban []UserBan) ([]UserBan, error) {
+	ban = slices.Clone(ban)
+	for i, b := range ban {
+		player, err := rpc.resolvePlayer(ctx, b.Player)
+		if err != nil {
+			return nil, err
+		}
+		if err := player.Validate(); err != nil {
+			return nil, err
+		}
+		ban[i].Player = player
+	}
+	return ban, nil
 }
 
 // BansRemove - Remove players from ban list
 func (rpc *RPCClient) BansRemove(ctx context.Context, player ...Player) error {
-	method := usage.NewMethod("bans").Add("remove").String()
-	r, err := rpc.core.CallWithContext(ctx, method, player)
+	return rpc.send(ctx, methodBansRemove.Name, player)
+}
+
+// BansRemoveWithResult - Remove players from ban list, returning the resulting list
+func (rpc *RPCClient) BansRemoveWithResult(ctx context.Context, player ...Player) ([]UserBan, error) {
+	r, err := rpc.call(ctx, methodBansRemove.Name, player)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	data, err := jsonrpc.DecodeRPCResult[[]UserBan](r)
+	if err != nil {
+		return nil, err
 	}
 
-	return r.Err()
+	return *data, nil
 }
 
 // BansClear - Clear all players in ban list
 func (rpc *RPCClient) BansClear(ctx context.Context) error {
-	method := usage.NewMethod("bans").Add("clear").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
+	return rpc.send(ctx, methodBansClear.Name)
+}
+
+// BansClearWithResult - Clear all players in ban list, returning the resulting list
+func (rpc *RPCClient) BansClearWithResult(ctx context.Context) ([]UserBan, error) {
+	r, err := rpc.call(ctx, methodBansClear.Name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return r.Err()
+	data, err := jsonrpc.DecodeRPCResult[[]UserBan](r)
+	if err != nil {
+		return nil, err
+	}
+
+	return *data, nil
 }
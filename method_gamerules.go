@@ -4,7 +4,6 @@ import (
 	"context"
 
 	"github.com/eterline/go-mc-smp/internal/jsonrpc"
-	"github.com/eterline/go-mc-smp/internal/usage"
 )
 
 // Endpoints are accessible at minecraft:gamerules
@@ -15,14 +14,13 @@ import (
 // /update  | Update game rule value                                    | gamerule: UntypedGameRule | gamerule: TypedGameRule
 
 // GamerulesGet - Get the available game rule keys and their current values
-func (rpc *RPCClient) GamerulesGet(ctx context.Context) ([]GameRule, error) {
-	method := usage.NewMethod("gamerules").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
+func (rpc *RPCClient) GamerulesGet(ctx context.Context) (GameRules, error) {
+	r, err := rpc.call(ctx, methodGamerules.Name)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := jsonrpc.DecodeRPCResult[[]GameRule](r)
+	data, err := jsonrpc.DecodeRPCResult[GameRules](r)
 	if err != nil {
 		return nil, err
 	}
@@ -31,13 +29,17 @@ func (rpc *RPCClient) GamerulesGet(ctx context.Context) ([]GameRule, error) {
 }
 
 // GamerulesUpdate - Update game rule value
+// The rule is checked against the game rule catalogue before the call.
+// Unknown keys are rejected unless allowed with WithCustomGameRules.
 func (rpc *RPCClient) GamerulesUpdate(ctx context.Context, rule GameRule) (*GameRule, error) {
+	if err := rpc.validateGameRule(rule); err != nil {
+		return nil, err
+	}
 
 	// for correct api usage
 	rule.Type = UntypedGameRule
 
-	method := usage.NewMethod("gamerules").Add("update").String()
-	r, err := rpc.core.CallWithContext(ctx, method, rule)
+	r, err := rpc.call(ctx, methodGamerulesUpdate.Name, rule)
 	if err != nil {
 		return nil, err
 	}
@@ -49,3 +51,11 @@ func (rpc *RPCClient) GamerulesUpdate(ctx context.Context, rule GameRule) (*Game
 
 	return data, nil
 }
+
+func (rpc *RPCClient) validateGameRule(rule GameRule) error {
+	key := GameRuleKey(rule.Key)
+	if _, known := LookupGameRule(key); !known && rpc.customGameRules[key] {
+		return validateCustomGameRule(rule, UntypedGameRule)
+	}
+	return ValidateGameRule(rule)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 
 	"github.com/eterline/go-mc-smp/internal/jsonrpc"
-	"github.com/eterline/go-mc-smp/internal/usage"
 )
 
 // Endpoints are accessible at minecraft:ip_bans
@@ -19,8 +18,7 @@ import (
 
 // IPBansGet - Get the ip ban list
 func (rpc *RPCClient) IPBansGet(ctx context.Context) ([]IPBan, error) {
-	method := usage.NewMethod("ip_bans").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
+	r, err := rpc.call(ctx, methodIPBans.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -35,44 +33,80 @@ func (rpc *RPCClient) IPBansGet(ctx context.Context) ([]IPBan, error) {
 
 // IPBansSet - Set the ip ban list
 func (rpc *RPCClient) IPBansSet(ctx context.Context, ban ...IPBan) error {
-	method := usage.NewMethod("ip_bans").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, ban)
+	return rpc.send(ctx, methodIPBansSet.Name, ban)
+}
+
+// IPBansSetWithResult - Set the ip ban list, returning the resulting list
+func (rpc *RPCClient) IPBansSetWithResult(ctx context.Context, ban ...IPBan) ([]IPBan, error) {
+	r, err := rpc.call(ctx, methodIPBansSet.Name, ban)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return r.Err()
+	data, err := jsonrpc.DecodeRPCResult[[]IPBan](r)
+	if err != nil {
+		return nil, err
+	}
+
+	return *data, nil
 }
 
 // IPBansAdd - Add players to the ip ban list
 func (rpc *RPCClient) IPBansAdd(ctx context.Context, ban ...IPBan) error {
-	method := usage.NewMethod("ip_bans").Add("add").String()
-	r, err := rpc.core.CallWithContext(ctx, method, ban)
+	return rpc.send(ctx, methodIPBansAdd.Name, ban)
+}
+
+// IPBansAddWithResult - Add players to the ip ban list, returning the resulting list
+func (rpc *RPCClient) IPBansAddWithResult(ctx context.Context, ban ...IPBan) ([]IPBan, error) {
+	r, err := rpc.call(ctx, methodIPBansAdd.Name, ban)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return r.Err()
+	data, err := jsonrpc.DecodeRPCResult[[]IPBan](r)
+	if err != nil {
+		return nil, err
+	}
+
+	return *data, nil
 }
 
 // IPBansRemove - Remove players from ip ban list
 func (rpc *RPCClient) IPBansRemove(ctx context.Context, player ...IPBan) error {
-	method := usage.NewMethod("ip_bans").Add("remove").String()
-	r, err := rpc.core.CallWithContext(ctx, method, player)
+	return rpc.send(ctx, methodIPBansRemove.Name, player)
+}
+
+// IPBansRemoveWithResult - Remove players from ip ban list, returning the resulting list
+func (rpc *RPCClient) IPBansRemoveWithResult(ctx context.Context, player ...IPBan) ([]IPBan, error) {
+	r, err := rpc.call(ctx, methodIPBansRemove.Name, player)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return r.Err()
+	data, err := jsonrpc.DecodeRPCResult[[]IPBan](r)
+	if err != nil {
+		return nil, err
+	}
+
+	return *data, nil
 }
 
 // IPBansClear - Clear all players in ip ban list
 func (rpc *RPCClient) IPBansClear(ctx context.Context) error {
-	method := usage.NewMethod("ip_bans").Add("clear").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
+	return rpc.send(ctx, methodIPBansClear.Name)
+}
+
+// IPBansClearWithResult - Clear all players in ip ban list, returning the resulting list
+func (rpc *RPCClient) IPBansClearWithResult(ctx context.Context) ([]IPBan, error) {
+	r, err := rpc.call(ctx, methodIPBansClear.Name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return r.Err()
+	data, err := jsonrpc.DecodeRPCResult[[]IPBan](r)
+	if err != nil {
+		return nil, err
+	}
+
+	return *data, nil
 }
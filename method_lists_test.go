@@ -0,0 +1,194 @@
+package gomcsmp_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+	"github.com/eterline/go-mc-smp/mcsmptest"
+	"github.com/google/uuid"
+)
+
+// listPlayers - two players which pass validation, the fixed test UUIDs do not
+func listPlayers() (steve, alex gomcsmp.Player) {
+	return playerWithID("Steve", uuid.New()), playerWithID("Alex", uuid.New())
+}
+
+func TestAllowlistWithResult(t *testing.T) {
+	ctx := context.Background()
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+	steve, alex := listPlayers()
+
+	r, err := client.AllowlistAddWithResult(ctx, steve, alex)
+	if err != nil {
+		t.Fatalf("AllowlistAddWithResult: %v", err)
+	}
+	if !r.Equal(registry(steve, alex)) {
+		t.Fatalf("after add = %v, want Steve and Alex", r.Players())
+	}
+
+	r, err = client.AllowlistRemoveWithResult(ctx, alex)
+	if err != nil {
+		t.Fatalf("AllowlistRemoveWithResult: %v", err)
+	}
+	if !r.Equal(registry(steve)) {
+		t.Fatalf("after remove = %v, want Steve", r.Players())
+	}
+
+	r, err = client.AllowlistSetWithResult(ctx, alex)
+	if err != nil {
+		t.Fatalf("AllowlistSetWithResult: %v", err)
+	}
+	if !r.Equal(registry(alex)) {
+		t.Fatalf("after set = %v, want Alex", r.Players())
+	}
+	if _, err := client.AllowlistSetWithResult(ctx, gomcsmp.NewPlayer("Steve")); err == nil {
+		t.Fatal("set accepted a player without UUID")
+	}
+
+	r, err = client.AllowlistClearWithResult(ctx)
+	if err != nil || r.Len() != 0 {
+		t.Fatalf("AllowlistClearWithResult = %v, %v, want empty", r.Players(), err)
+	}
+}
+
+func TestAllowlistVoidMethods(t *testing.T) {
+	ctx := context.Background()
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+	steve, _ := listPlayers()
+
+	if err := client.AllowlistAdd(ctx, steve); err != nil {
+		t.Fatalf("AllowlistAdd: %v", err)
+	}
+	if err := client.AllowlistSet(ctx, gomcsmp.NewPlayer("Alex")); err == nil {
+		t.Fatal("set accepted a player without UUID")
+	}
+	if n := srv.CallCount("minecraft:allowlist/set"); n != 0 {
+		t.Fatalf("invalid set sent %d times", n)
+	}
+	if err := client.AllowlistRemove(ctx, steve); err != nil {
+		t.Fatalf("AllowlistRemove: %v", err)
+	}
+	if n := len(srv.State().Allowlist); n != 0 {
+		t.Fatalf("allowlist holds %d players, want none", n)
+	}
+
+	// the void methods send only the call, the list is never read back
+	if n := srv.CallCount("minecraft:allowlist"); n != 0 {
+		t.Fatalf("allowlist read %d times", n)
+	}
+}
+
+func TestBansWithResult(t *testing.T) {
+	ctx := context.Background()
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+	steveP, alexP := listPlayers()
+
+	steve := gomcsmp.NewPermanentUserBan(steveP, "Griefing", "Server")
+	alex := gomcsmp.NewPermanentUserBan(alexP, "Spam", "Server")
+
+	bans, err := client.BansAddWithResult(ctx, steve, alex)
+	if err != nil || len(bans) != 2 {
+		t.Fatalf("BansAddWithResult = %+v, %v, want two bans", bans, err)
+	}
+	if _, err := client.BansAddWithResult(ctx, gomcsmp.NewPermanentUserBan(gomcsmp.NewPlayer("no spaces"), "", "")); err == nil {
+		t.Fatal("add accepted an invalid player name")
+	}
+
+	bans, err = client.BansRemoveWithResult(ctx, steve.Player)
+	if err != nil || len(bans) != 1 || bans[0].Player.Name != "Alex" {
+		t.Fatalf("BansRemoveWithResult = %+v, %v, want Alex", bans, err)
+	}
+
+	bans, err = client.BansSetWithResult(ctx, steve)
+	if err != nil || len(bans) != 1 || bans[0].Reason != "Griefing" {
+		t.Fatalf("BansSetWithResult = %+v, %v, want Steve", bans, err)
+	}
+
+	bans, err = client.BansClearWithResult(ctx)
+	if err != nil || len(bans) != 0 {
+		t.Fatalf("BansClearWithResult = %+v, %v, want none", bans, err)
+	}
+}
+
+func TestIPBansWithResult(t *testing.T) {
+	ctx := context.Background()
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+
+	first := gomcsmp.NewPermanentIPBan(net.ParseIP("192.168.0.10"), "Spam", "Server")
+	second := gomcsmp.NewPermanentIPBan(net.ParseIP("192.168.0.11"), "Spam", "Server")
+
+	bans, err := client.IPBansAddWithResult(ctx, first, second)
+	if err != nil || len(bans) != 2 {
+		t.Fatalf("IPBansAddWithResult = %+v, %v, want two bans", bans, err)
+	}
+
+	bans, err = client.IPBansRemoveWithResult(ctx, first)
+	if err != nil || len(bans) != 1 || bans[0].IP != "192.168.0.11" {
+		t.Fatalf("IPBansRemoveWithResult = %+v, %v, want 192.168.0.11", bans, err)
+	}
+
+	bans, err = client.IPBansSetWithResult(ctx, first)
+	if err != nil || len(bans) != 1 || bans[0].IP != "192.168.0.10" {
+		t.Fatalf("IPBansSetWithResult = %+v, %v, want 192.168.0.10", bans, err)
+	}
+
+	bans, err = client.IPBansClearWithResult(ctx)
+	if err != nil || len(bans) != 0 {
+		t.Fatalf("IPBansClearWithResult = %+v, %v, want none", bans, err)
+	}
+}
+
+func TestOperatorsWithResult(t *testing.T) {
+	ctx := context.Background()
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+	steveP, alexP := listPlayers()
+
+	steve := gomcsmp.NewOperator(steveP, 4, true)
+	alex := gomcsmp.NewOperator(alexP, 2, false)
+
+	ops, err := client.OperatorsAddWithResult(ctx, steve, alex)
+	if err != nil || len(ops) != 2 {
+		t.Fatalf("OperatorsAddWithResult = %+v, %v, want two operators", ops, err)
+	}
+
+	ops, err = client.OperatorsRemoveWithResult(ctx, steve.Player)
+	if err != nil || len(ops) != 1 || ops[0].Player.Name != "Alex" {
+		t.Fatalf("OperatorsRemoveWithResult = %+v, %v, want Alex", ops, err)
+	}
+
+	ops, err = client.OperatorsSetWithResult(ctx, steve)
+	if err != nil || len(ops) != 1 || ops[0].Permission != 4 || !ops[0].BypassesPlayerLimit {
+		t.Fatalf("OperatorsSetWithResult = %+v, %v, want Steve at level 4", ops, err)
+	}
+
+	ops, err = client.OperatorsClearWithResult(ctx)
+	if err != nil || len(ops) != 0 {
+		t.Fatalf("OperatorsClearWithResult = %+v, %v, want none", ops, err)
+	}
+}
+
+func TestVoidMethodsReportErrors(t *testing.T) {
+	ctx := context.Background()
+	plan := mcsmptest.NewFaultPlan(
+		mcsmptest.FailWith("minecraft:bans/clear", "boom"),
+		mcsmptest.FailWith("minecraft:operators/clear", "boom"),
+	)
+	client := mcsmptest.Start(t, mcsmptest.WithFaultPlan(plan)).TestClient(t)
+
+	if err := client.BansClear(ctx); err == nil {
+		t.Fatal("BansClear succeeded, want the server error")
+	}
+	if err := client.OperatorsClear(ctx); err == nil {
+		t.Fatal("OperatorsClear succeeded, want the server error")
+	}
+	if err := client.IPBansClear(ctx); err != nil {
+		t.Fatalf("IPBansClear: %v", err)
+	}
+}
@@ -2,9 +2,9 @@ package gomcsmp
 
 import (
 	"context"
+	"slices"
 
 	"github.com/eterline/go-mc-smp/internal/jsonrpc"
-	"github.com/eterline/go-mc-smp/internal/usage"
 )
 
 // Endpoints are accessible at minecraft:operators
@@ -19,8 +19,7 @@ import (
 
 // OperatorsGet - Get all oped players
 func (rpc *RPCClient) OperatorsGet(ctx context.Context) ([]Operator, error) {
-	method := usage.NewMethod("operators").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
+	r, err := rpc.call(ctx, methodOperators.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -35,44 +34,106 @@ func (rpc *RPCClient) OperatorsGet(ctx context.Context) ([]Operator, error) {
 
 // OperatorsSet - Set all oped players
 func (rpc *RPCClient) OperatorsSet(ctx context.Context, p ...Operator) error {
-	method := usage.NewMethod("operators").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, p)
+	return rpc.send(ctx, methodOperatorsSet.Name, p)
+}
+
+// OperatorsSetWithResult - Set all oped players, returning the resulting list
+func (rpc *RPCClient) OperatorsSetWithResult(ctx context.Context, p ...Operator) ([]Operator, error) {
+	r, err := rpc.call(ctx, methodOperatorsSet.Name, p)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return r.Err()
+	data, err := jsonrpc.DecodeRPCResult[[]Operator](r)
+	if err != nil {
+		return nil, err
+	}
+
+	return *data, nil
 }
 
 // OperatorsAdd - Op players
 func (rpc *RPCClient) OperatorsAdd(ctx context.Context, p ...Operator) error {
-	method := usage.NewMethod("operators").Add("add").String()
-	r, err := rpc.core.CallWithContext(ctx, method, p)
+	p, err := rpc.operatorsAddParams(ctx, p)
 	if err != nil {
 		return err
 	}
 
-	return r.Err()
+	return rpc.send(ctx, methodOperatorsAdd.Name, p)
+}
+
+// OperatorsAddWithResult - Op players, returning the resulting list
+func (rpc *RPCClient) OperatorsAddWithResult(ctx context.Context, p ...Operator) ([]Operator, error) {
+	p, err := rpc.operatorsAddParams(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := rpc.call(ctx, methodOperatorsAdd.Name, p)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := jsonrpc.DecodeRPCResult[[]Operator](r)
+	if err != nil {
+		return nil, err
+	}
+
+	return *data, nil
+}
+
+// operatorsAddParams - resolves and validates the players of the entries to add.
+func (rpc *RPCClient) operatorsAddParams(ctx context.Context, p []Operator) ([]Operator, error) {
+	p = slices.Clone(p)
+	for i, op := range p {
+		player, err := rpc.resolvePlayer(ctx, op.Player)
+		if err != nil {
+			return nil, err
+		}
+		if err := player.Validate(); err != nil {
+			return nil, err
+		}
+		p[i].Player = player
+	}
+	return p, nil
 }
 
 // OperatorsRemove - Deop players
 func (rpc *RPCClient) OperatorsRemove(ctx context.Context, p ...Player) error {
-	method := usage.NewMethod("operators").Add("remove").String()
-	r, err := rpc.core.CallWithContext(ctx, method, p)
+	return rpc.send(ctx, methodOperatorsRemove.Name, p)
+}
+
+// OperatorsRemoveWithResult - Deop players, returning the resulting list
+func (rpc *RPCClient) OperatorsRemoveWithResult(ctx context.Context, p ...Player) ([]Operator, error) {
+	r, err := rpc.call(ctx, methodOperatorsRemove.Name, p)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	data, err := jsonrpc.DecodeRPCResult[[]Operator](r)
+	if err != nil {
+		return nil, err
 	}
 
-	return r.Err()
+	return *data, nil
 }
 
 // OperatorsClear - Deop all players
 func (rpc *RPCClient) OperatorsClear(ctx context.Context) error {
-	method := usage.NewMethod("operators").Add("clear").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
+	return rpc.send(ctx, methodOperatorsClear.Name)
+}
+
+// OperatorsClearWithResult - Deop all players, returning the resulting list
+func (rpc *RPCClient) OperatorsClearWithResult(ctx context.Context) ([]Operator, error) {
+	r, err := rpc.call(ctx, methodOperatorsClear.Name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return r.Err()
+	data, err := jsonrpc.DecodeRPCResult[[]Operator](r)
+	if err != nil {
+		return nil, err
+	}
+
+	return *data, nil
 }
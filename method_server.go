@@ -4,7 +4,6 @@ import (
 	"context"
 
 	"github.com/eterline/go-mc-smp/internal/jsonrpc"
-	"github.com/eterline/go-mc-smp/internal/usage"
 )
 
 // Endpoints are accessible at minecraft:server
@@ -18,8 +17,7 @@ import (
 
 // ServerStatus - Get server status
 func (rpc *RPCClient) ServerStatus(ctx context.Context) (*ServerState, error) {
-	method := usage.NewMethod("server").Add("status").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
+	r, err := rpc.call(ctx, methodServerStatus.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -34,8 +32,7 @@ func (rpc *RPCClient) ServerStatus(ctx context.Context) (*ServerState, error) {
 
 // ServerSave - Save server state
 func (rpc *RPCClient) ServerSave(ctx context.Context, flush bool) (bool, error) {
-	method := usage.NewMethod("server").Add("save").String()
-	r, err := rpc.core.CallWithContext(ctx, method, flush)
+	r, err := rpc.call(ctx, methodServerSave.Name, flush)
 	if err != nil {
 		return false, err
 	}
@@ -50,8 +47,7 @@ func (rpc *RPCClient) ServerSave(ctx context.Context, flush bool) (bool, error)
 
 // ServerStop - Stop server
 func (rpc *RPCClient) ServerStop(ctx context.Context) (bool, error) {
-	method := usage.NewMethod("server").Add("stop").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
+	r, err := rpc.call(ctx, methodServerStop.Name)
 	if err != nil {
 		return false, err
 	}
@@ -66,8 +62,7 @@ func (rpc *RPCClient) ServerStop(ctx context.Context) (bool, error) {
 
 // ServerSystemMessage - Send a system message
 func (rpc *RPCClient) ServerSystemMessage(ctx context.Context, message SystemMessage) (bool, error) {
-	method := usage.NewMethod("server").Add("system_message").String()
-	r, err := rpc.core.CallWithContext(ctx, method, message)
+	r, err := rpc.call(ctx, methodServerSystemMessage.Name, message)
 	if err != nil {
 		return false, err
 	}
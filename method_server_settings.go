@@ -3,9 +3,6 @@ package gomcsmp
 import (
 	"context"
 	"time"
-
-	"github.com/eterline/go-mc-smp/internal/jsonrpc"
-	"github.com/eterline/go-mc-smp/internal/usage"
 )
 
 // Endpoints are accessible at minecraft:serversettings
@@ -57,689 +54,258 @@ import (
 
 // SettingsAutosave - Get whether automatic world saving is enabled on the server
 func (rpc *RPCClient) SettingsAutosave(ctx context.Context) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("autosave").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingAutosave.Get(ctx, rpc)
 }
 
 // SettingsAutosaveSet - Enable or disable automatic world saving on the server
 func (rpc *RPCClient) SettingsAutosaveSet(ctx context.Context, enable bool) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("autosave").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, enable)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingAutosave.Set(ctx, rpc, enable)
 }
 
 // ===========
 
 // SettingsDifficulty - Get the current difficulty level of the server
 func (rpc *RPCClient) SettingsDifficulty(ctx context.Context) (string, error) {
-	method := usage.NewMethod("serversettings").Add("difficulty").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return "", err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[string](r)
-	if err != nil {
-		return "", err
-	}
-
-	return *data, nil
+	return settingDifficultyName.Get(ctx, rpc)
 }
 
 // SettingsDifficultySet - Set the difficulty level of the server
 func (rpc *RPCClient) SettingsDifficultySet(ctx context.Context, difficulty string) (string, error) {
-	method := usage.NewMethod("serversettings").Add("difficulty").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, difficulty)
-	if err != nil {
-		return "", err
-	}
+	return settingDifficultyName.Set(ctx, rpc, difficulty)
+}
 
-	data, err := jsonrpc.DecodeRPCResult[string](r)
-	if err != nil {
-		return "", err
-	}
+// SettingsDifficultyLevel - Get the current difficulty level of the server as Difficulty
+func (rpc *RPCClient) SettingsDifficultyLevel(ctx context.Context) (Difficulty, error) {
+	return SettingDifficulty.Get(ctx, rpc)
+}
 
-	return *data, nil
+// SettingsDifficultyLevelSet - Set the difficulty level of the server, rejecting unknown levels before the call
+func (rpc *RPCClient) SettingsDifficultyLevelSet(ctx context.Context, difficulty Difficulty) (Difficulty, error) {
+	return SettingDifficulty.Set(ctx, rpc, difficulty)
 }
 
 // ===========
 
 // SettingsEnforceAllowlist - Get whether allowlist enforcement is enabled (kicks players immediately when removed from allowlist)
 func (rpc *RPCClient) SettingsEnforceAllowlist(ctx context.Context) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("enforce_allowlist").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingEnforceAllowlist.Get(ctx, rpc)
 }
 
 // SettingsEnforceAllowlistSet - Enable or disable allowlist enforcement (when enabled, players are kicked immediately upon removal from allowlist)
 func (rpc *RPCClient) SettingsEnforceAllowlistSet(ctx context.Context, enforce bool) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("enforce_allowlist").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, enforce)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingEnforceAllowlist.Set(ctx, rpc, enforce)
 }
 
 // ===========
 
 // SettingsUseAllowlist - Get whether the allowlist is enabled on the server
 func (rpc *RPCClient) SettingsUseAllowlist(ctx context.Context) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("use_allowlist").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingUseAllowlist.Get(ctx, rpc)
 }
 
 // SettingsUseAllowlistSet - Enable or disable the allowlist on the server (controls whether only allowlisted players can join)
 func (rpc *RPCClient) SettingsUseAllowlistSet(ctx context.Context, use bool) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("use_allowlist").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, use)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingUseAllowlist.Set(ctx, rpc, use)
 }
 
 // ===========
 
 // SettingsMaxPlayers - Get the maximum number of players allowed to connect to the server
 func (rpc *RPCClient) SettingsMaxPlayers(ctx context.Context) (int, error) {
-	method := usage.NewMethod("serversettings").Add("max_players").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return *data, nil
+	return SettingMaxPlayers.Get(ctx, rpc)
 }
 
 // SettingsMaxPlayersSet - Set the maximum number of players allowed to connect to the server
 func (rpc *RPCClient) SettingsMaxPlayersSet(ctx context.Context, max int) (int, error) {
-	method := usage.NewMethod("serversettings").Add("max_players").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, max)
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return *data, nil
+	return SettingMaxPlayers.Set(ctx, rpc, max)
 }
 
 // ===========
 
-func intSecDuration(secPtr *int) time.Duration {
-	if secPtr == nil {
-		return 0
-	}
-	return (time.Duration(*secPtr) * time.Second)
-}
-
-func durationIntSec(d time.Duration) int {
-	return int(d.Seconds())
-}
-
 // SettingsPauseWhenEmptySeconds - Get the number of seconds before the game is automatically paused when no players are online
 func (rpc *RPCClient) SettingsPauseWhenEmptySeconds(ctx context.Context) (time.Duration, error) {
-	method := usage.NewMethod("serversettings").Add("pause_when_empty_seconds").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return intSecDuration(data), nil
+	return SettingPauseWhenEmpty.Get(ctx, rpc)
 }
 
 // SettingsPauseWhenEmptySecondsSet - Set the number of seconds before the game is automatically paused when no players are online
 func (rpc *RPCClient) SettingsPauseWhenEmptySecondsSet(ctx context.Context, duration time.Duration) (time.Duration, error) {
-	method := usage.NewMethod("serversettings").Add("pause_when_empty_seconds").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, durationIntSec(duration))
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return intSecDuration(data), nil
+	return SettingPauseWhenEmpty.Set(ctx, rpc, duration)
 }
 
 // ===========
 
 // SettingsPlayerIdleTimeout - Get the number of seconds before idle players are automatically kicked from the server
 func (rpc *RPCClient) SettingsPlayerIdleTimeout(ctx context.Context) (time.Duration, error) {
-	method := usage.NewMethod("serversettings").Add("player_idle_timeout").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return intSecDuration(data), nil
+	return SettingPlayerIdleTimeout.Get(ctx, rpc)
 }
 
 // SettingsPlayerIdleTimeoutSet - Set the number of seconds before idle players are automatically kicked from the server
 func (rpc *RPCClient) SettingsPlayerIdleTimeoutSet(ctx context.Context, duration time.Duration) (time.Duration, error) {
-	method := usage.NewMethod("serversettings").Add("player_idle_timeout").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, durationIntSec(duration))
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return intSecDuration(data), nil
+	return SettingPlayerIdleTimeout.Set(ctx, rpc, duration)
 }
 
 // ===========
 
 // SettingsAllowFlight - Get whether flight is allowed for players in Survival mode
 func (rpc *RPCClient) SettingsAllowFlight(ctx context.Context) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("allow_flight").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingAllowFlight.Get(ctx, rpc)
 }
 
 // SettingsAllowFlightSet - Set whether flight is allowed for players in Survival mode
 func (rpc *RPCClient) SettingsAllowFlightSet(ctx context.Context, allow bool) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("allow_flight").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, allow)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingAllowFlight.Set(ctx, rpc, allow)
 }
 
 // ===========
 
 // SettingsMotd - Get the server's message of the day displayed to players
 func (rpc *RPCClient) SettingsMotd(ctx context.Context) (string, error) {
-	method := usage.NewMethod("serversettings").Add("motd").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return "", err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[string](r)
-	if err != nil {
-		return "", err
-	}
-
-	return *data, nil
+	return SettingMotd.Get(ctx, rpc)
 }
 
 // SettingsMotdSet - Set the server's message of the day displayed to players
 func (rpc *RPCClient) SettingsMotdSet(ctx context.Context, motd string) (string, error) {
-	method := usage.NewMethod("serversettings").Add("motd").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, motd)
-	if err != nil {
-		return "", err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[string](r)
-	if err != nil {
-		return "", err
-	}
-
-	return *data, nil
+	return SettingMotd.Set(ctx, rpc, motd)
 }
 
 // ===========
 
 // SettingsSpawnProtectionRadius - Get the spawn protection radius in blocks
 func (rpc *RPCClient) SettingsSpawnProtectionRadius(ctx context.Context) (int, error) {
-	method := usage.NewMethod("serversettings").Add("spawn_protection_radius").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return *data, nil
+	return SettingSpawnProtectionRadius.Get(ctx, rpc)
 }
 
 // SettingsSpawnProtectionRadiusSet - Set the spawn protection radius in blocks
 func (rpc *RPCClient) SettingsSpawnProtectionRadiusSet(ctx context.Context, radius int) (int, error) {
-	method := usage.NewMethod("serversettings").Add("spawn_protection_radius").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, radius)
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return *data, nil
+	return SettingSpawnProtectionRadius.Set(ctx, rpc, radius)
 }
 
 // ===========
 
 // SettingsForceGameMode - Get whether players are forced to use the server's default game mode
 func (rpc *RPCClient) SettingsForceGameMode(ctx context.Context) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("force_game_mode").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingForceGameMode.Get(ctx, rpc)
 }
 
 // SettingsForceGameModeSet - Set whether players are forced to use the server's default game mode
 func (rpc *RPCClient) SettingsForceGameModeSet(ctx context.Context, forced bool) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("force_game_mode").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, forced)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingForceGameMode.Set(ctx, rpc, forced)
 }
 
 // ===========
 
 // SettingsGameMode - Get the server's default game mode
 func (rpc *RPCClient) SettingsGameMode(ctx context.Context) (string, error) {
-	method := usage.NewMethod("serversettings").Add("game_mode").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return "", err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[string](r)
-	if err != nil {
-		return "", err
-	}
-
-	return *data, nil
+	return settingGameModeName.Get(ctx, rpc)
 }
 
 // SettingsGameModeSet - Set the server's default game mode
 func (rpc *RPCClient) SettingsGameModeSet(ctx context.Context, gamemode string) (string, error) {
-	method := usage.NewMethod("serversettings").Add("game_mode").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, gamemode)
-	if err != nil {
-		return "", err
-	}
+	return settingGameModeName.Set(ctx, rpc, gamemode)
+}
 
-	data, err := jsonrpc.DecodeRPCResult[string](r)
-	if err != nil {
-		return "", err
-	}
+// SettingsDefaultGameMode - Get the server's default game mode as GameMode
+func (rpc *RPCClient) SettingsDefaultGameMode(ctx context.Context) (GameMode, error) {
+	return SettingGameMode.Get(ctx, rpc)
+}
 
-	return *data, nil
+// SettingsDefaultGameModeSet - Set the server's default game mode, rejecting unknown modes before the call
+func (rpc *RPCClient) SettingsDefaultGameModeSet(ctx context.Context, gamemode GameMode) (GameMode, error) {
+	return SettingGameMode.Set(ctx, rpc, gamemode)
 }
 
 // ===========
 
 // SettingsViewDistance - Get the server's view distance in chunks
 func (rpc *RPCClient) SettingsViewDistance(ctx context.Context) (int, error) {
-	method := usage.NewMethod("serversettings").Add("view_distance").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return *data, nil
+	return SettingViewDistance.Get(ctx, rpc)
 }
 
 // SettingsViewDistanceSet - Set the server's view distance in chunks
 func (rpc *RPCClient) SettingsViewDistanceSet(ctx context.Context, distance int) (int, error) {
-	method := usage.NewMethod("serversettings").Add("view_distance").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, distance)
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return *data, nil
+	return SettingViewDistance.Set(ctx, rpc, distance)
 }
 
 // ===========
 
 // SettingsSimulationDistance - Get the server's simulation distance in chunks
 func (rpc *RPCClient) SettingsSimulationDistance(ctx context.Context) (int, error) {
-	method := usage.NewMethod("serversettings").Add("simulation_distance").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return *data, nil
+	return SettingSimulationDistance.Get(ctx, rpc)
 }
 
 // SettingsSimulationDistanceSet - Set the server's simulation distance in chunks
 func (rpc *RPCClient) SettingsSimulationDistanceSet(ctx context.Context, distance int) (int, error) {
-	method := usage.NewMethod("serversettings").Add("simulation_distance").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, distance)
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return *data, nil
+	return SettingSimulationDistance.Set(ctx, rpc, distance)
 }
 
 // ===========
 
 // SettingsAcceptTransfers - Get whether the server accepts player transfers from other servers
 func (rpc *RPCClient) SettingsAcceptTransfers(ctx context.Context) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("accept_transfers").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingAcceptTransfers.Get(ctx, rpc)
 }
 
 // SettingsAcceptTransfersSet - Set whether the server accepts player transfers from other servers
 func (rpc *RPCClient) SettingsAcceptTransfersSet(ctx context.Context, accept bool) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("accept_transfers").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, accept)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingAcceptTransfers.Set(ctx, rpc, accept)
 }
 
 // ===========
 
 // SettingsStatusHeartbeatInterval - Get the interval in seconds between server status heartbeats
 func (rpc *RPCClient) SettingsStatusHeartbeatInterval(ctx context.Context) (time.Duration, error) {
-	method := usage.NewMethod("serversettings").Add("status_heartbeat_interval").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return intSecDuration(data), nil
+	return SettingStatusHeartbeatInterval.Get(ctx, rpc)
 }
 
 // SettingsStatusHeartbeatIntervalSet - Set the interval in seconds between server status heartbeats
 func (rpc *RPCClient) SettingsStatusHeartbeatIntervalSet(ctx context.Context, interval time.Duration) (time.Duration, error) {
-	method := usage.NewMethod("serversettings").Add("status_heartbeat_interval").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, durationIntSec(interval))
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return intSecDuration(data), nil
+	return SettingStatusHeartbeatInterval.Set(ctx, rpc, interval)
 }
 
 // ===========
 
 // SettingsOperatorUserPermissionLevel - Get the permission level required for operator commands
 func (rpc *RPCClient) SettingsOperatorUserPermissionLevel(ctx context.Context) (int, error) {
-	method := usage.NewMethod("serversettings").Add("operator_user_permission_level").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return *data, nil
+	return SettingOperatorUserPermissionLevel.Get(ctx, rpc)
 }
 
 // SettingsOperatorUserPermissionLevelSet - Set the permission level required for operator commands
 func (rpc *RPCClient) SettingsOperatorUserPermissionLevelSet(ctx context.Context, level int) (int, error) {
-	method := usage.NewMethod("serversettings").Add("operator_user_permission_level").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, level)
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return *data, nil
+	return SettingOperatorUserPermissionLevel.Set(ctx, rpc, level)
 }
 
 // ===========
 
 // SettingsHideOnlinePlayers - Get whether the server hides online player information from status queries
 func (rpc *RPCClient) SettingsHideOnlinePlayers(ctx context.Context) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("hide_online_players").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingHideOnlinePlayers.Get(ctx, rpc)
 }
 
 // SettingsHideOnlinePlayersSet - Set whether the server hides online player information from status queries
 func (rpc *RPCClient) SettingsHideOnlinePlayersSet(ctx context.Context, hide bool) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("hide_online_players").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, hide)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingHideOnlinePlayers.Set(ctx, rpc, hide)
 }
 
 // ===========
 
 // SettingsStatusReplies - Get whether the server responds to connection status requests
 func (rpc *RPCClient) SettingsStatusReplies(ctx context.Context) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("status_replies").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingStatusReplies.Get(ctx, rpc)
 }
 
 // SettingsStatusRepliesSet - Set whether the server responds to connection status requests
 func (rpc *RPCClient) SettingsStatusRepliesSet(ctx context.Context, enabled bool) (bool, error) {
-	method := usage.NewMethod("serversettings").Add("status_replies").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, enabled)
-	if err != nil {
-		return false, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[bool](r)
-	if err != nil {
-		return false, err
-	}
-
-	return *data, nil
+	return SettingStatusReplies.Set(ctx, rpc, enabled)
 }
 
 // ===========
 
 // SettingsEntityBroadcastRange - Get the entity broadcast range as a percentage
 func (rpc *RPCClient) SettingsEntityBroadcastRange(ctx context.Context) (int, error) {
-	method := usage.NewMethod("serversettings").Add("entity_broadcast_range").String()
-	r, err := rpc.core.CallWithContext(ctx, method)
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return *data, nil
+	return SettingEntityBroadcastRange.Get(ctx, rpc)
 }
 
 // SettingsEntityBroadcastRangeSet - Set the entity broadcast range as a percentage
 func (rpc *RPCClient) SettingsEntityBroadcastRangeSet(ctx context.Context, percentage_points int) (int, error) {
-	method := usage.NewMethod("serversettings").Add("entity_broadcast_range").Add("set").String()
-	r, err := rpc.core.CallWithContext(ctx, method, percentage_points)
-	if err != nil {
-		return 0, err
-	}
-
-	data, err := jsonrpc.DecodeRPCResult[int](r)
-	if err != nil {
-		return 0, err
-	}
-
-	return *data, nil
+	return SettingEntityBroadcastRange.Set(ctx, rpc, percentage_points)
 }
@@ -0,0 +1,366 @@
+package gomcsmp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrProfileNotFound = errors.New("profile not found")
+
+// ProfileResolver - maps player names to UUIDs and back.
+// Implementations return ErrProfileNotFound for unknown players.
+type ProfileResolver interface {
+	ResolveName(ctx context.Context, name string) (Player, error)
+	ResolveID(ctx context.Context, id uuid.UUID) (Player, error)
+}
+
+// WithProfileResolver - fills missing player UUIDs through the resolver
+// before AllowlistAdd, BansAdd and OperatorsAdd calls.
+// Players the resolver does not know are sent without a UUID.
+func WithProfileResolver(r ProfileResolver) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.resolver = r
+	}
+}
+
+// resolvePlayer - returns the player with its UUID filled in when a resolver is set.
+func (rpc *RPCClient) resolvePlayer(ctx context.Context, p Player) (Player, error) {
+	if rpc.resolver == nil || p.ID != nil || p.Name == "" {
+		return p, nil
+	}
+
+	resolved, err := rpc.resolver.ResolveName(ctx, p.Name)
+	if errors.Is(err, ErrProfileNotFound) {
+		return p, nil
+	}
+	if err != nil {
+		return p, fmt.Errorf("resolve player '%s': %w", p.Name, err)
+	}
+
+	p.ID = resolved.ID
+	return p, nil
+}
+
+// resolvePlayers - returns a copy of the players with UUIDs filled in.
+func (rpc *RPCClient) resolvePlayers(ctx context.Context, players []Player) ([]Player, error) {
+	if rpc.resolver == nil {
+		return players, nil
+	}
+
+	out := make([]Player, len(players))
+	for i, p := range players {
+		resolved, err := rpc.resolvePlayer(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = resolved
+	}
+	return out, nil
+}
+
+// ============
+
+// UserCacheEntry - one record of the server's usercache.json.
+type UserCacheEntry struct {
+	Name      string    `json:"name"`
+	UUID      uuid.UUID `json:"uuid"`
+	ExpiresOn BanExpiry `json:"expiresOn"`
+}
+
+// Player - returns the player described by the entry.
+func (e UserCacheEntry) Player() Player {
+	id := e.UUID
+	return Player{
+		Name: e.Name,
+		ID:   &id,
+	}
+}
+
+// ReadUserCache - reads entries in the usercache.json format.
+func ReadUserCache(rd io.Reader) ([]UserCacheEntry, error) {
+	entries := []UserCacheEntry{}
+	if err := json.NewDecoder(rd).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("read user cache: %w", err)
+	}
+	return entries, nil
+}
+
+// UserCacheResolver - resolves profiles from the usercache.json of a server.
+// The file is read again whenever its modification time changes.
+type UserCacheResolver struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	cache   *PlayerRegistry
+}
+
+// NewUserCacheResolver - creates a resolver reading the usercache.json at path.
+func NewUserCacheResolver(path string) *UserCacheResolver {
+	return &UserCacheResolver{
+		path: path,
+	}
+}
+
+func (r *UserCacheResolver) ResolveName(ctx context.Context, name string) (Player, error) {
+	cache, err := r.load()
+	if err != nil {
+		return Player{}, err
+	}
+
+	p, ok := cache.PlayerByName(name)
+	if !ok || p.ID == nil {
+		return Player{}, fmt.Errorf("%w: '%s'", ErrProfileNotFound, name)
+	}
+	return p, nil
+}
+
+func (r *UserCacheResolver) ResolveID(ctx context.Context, id uuid.UUID) (Player, error) {
+	cache, err := r.load()
+	if err != nil {
+		return Player{}, err
+	}
+
+	p, ok := cache.PlayerByID(id)
+	if !ok {
+		return Player{}, fmt.Errorf("%w: %s", ErrProfileNotFound, id)
+	}
+	return p, nil
+}
+
+func (r *UserCacheResolver) load() (*PlayerRegistry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("stat user cache: %w", err)
+	}
+	if r.cache != nil && info.ModTime().Equal(r.modTime) {
+		return r.cache, nil
+	}
+
+	f, err := os.Open(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("open user cache: %w", err)
+	}
+	defer f.Close()
+
+	entries, err := ReadUserCache(f)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := newPlayerRegistry(len(entries))
+	for _, e := range entries {
+		cache.Add(e.Player())
+	}
+
+	r.cache, r.modTime = cache, info.ModTime()
+	return cache, nil
+}
+
+// ============
+
+// CachingResolver - caches profiles resolved by another resolver for a TTL.
+// When a path is given the cache is loaded from it on creation
+// and written back, in the usercache.json format, after every lookup that fills it.
+// A failed write does not fail the lookup, it is reported by Err and retried by Flush.
+type CachingResolver struct {
+	next ProfileResolver
+	ttl  time.Duration
+	path string
+
+	mu      sync.Mutex
+	entries map[string]UserCacheEntry // keyed by lower-case name
+	saveErr error
+}
+
+// NewCachingResolver - creates a caching layer over next.
+// An empty path keeps the cache in memory only, a non-positive ttl keeps profiles forever.
+func NewCachingResolver(next ProfileResolver, ttl time.Duration, path string) (*CachingResolver, error) {
+	r := &CachingResolver{
+		next:    next,
+		ttl:     ttl,
+		path:    path,
+		entries: map[string]UserCacheEntry{},
+	}
+
+	if path == "" {
+		return r, nil
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open profile cache: %w", err)
+	}
+	defer f.Close()
+
+	entries, err := ReadUserCache(f)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if !e.ExpiresOn.Expired() {
+			r.entries[strings.ToLower(e.Name)] = e
+		}
+	}
+
+	return r, nil
+}
+
+func (r *CachingResolver) ResolveName(ctx context.Context, name string) (Player, error) {
+	if p, ok := r.lookup(func(e UserCacheEntry) bool {
+		return strings.EqualFold(e.Name, name)
+	}); ok {
+		return p, nil
+	}
+
+	p, err := r.next.ResolveName(ctx, name)
+	if err != nil {
+		return Player{}, err
+	}
+	r.store(p)
+	return p, nil
+}
+
+func (r *CachingResolver) ResolveID(ctx context.Context, id uuid.UUID) (Player, error) {
+	if p, ok := r.lookup(func(e UserCacheEntry) bool {
+		return e.UUID == id
+	}); ok {
+		return p, nil
+	}
+
+	p, err := r.next.ResolveID(ctx, id)
+	if err != nil {
+		return Player{}, err
+	}
+	r.store(p)
+	return p, nil
+}
+
+// Err - returns the error of the last failed cache write,
+// nil when no write failed since the last successful one.
+func (r *CachingResolver) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.saveErr
+}
+
+// Flush - writes the cache to its file, e.g. after Err reported a failed write.
+func (r *CachingResolver) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.save()
+}
+
+// Forget - drops all cached profiles.
+func (r *CachingResolver) Forget() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = map[string]UserCacheEntry{}
+	return r.save()
+}
+
+func (r *CachingResolver) lookup(match func(UserCacheEntry) bool) (Player, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, e := range r.entries {
+		if !match(e) {
+			continue
+		}
+		if e.ExpiresOn.Expired() {
+			delete(r.entries, key)
+			return Player{}, false
+		}
+		return e.Player(), true
+	}
+	return Player{}, false
+}
+
+func (r *CachingResolver) store(p Player) {
+	if p.Name == "" || p.ID == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// a renamed UUID must not stay reachable under its old name
+	for key, e := range r.entries {
+		if e.UUID == *p.ID {
+			delete(r.entries, key)
+		}
+	}
+
+	expires := Permanent()
+	if r.ttl > 0 {
+		expires = ExpiresAfter(r.ttl)
+	}
+
+	r.entries[strings.ToLower(p.Name)] = UserCacheEntry{
+		Name:      p.Name,
+		UUID:      *p.ID,
+		ExpiresOn: expires,
+	}
+	r.save()
+}
+
+// save - writes the cache to its file, replacing it atomically, and records the outcome.
+func (r *CachingResolver) save() error {
+	r.saveErr = r.write()
+	return r.saveErr
+}
+
+func (r *CachingResolver) write() error {
+	if r.path == "" {
+		return nil
+	}
+
+	entries := make([]UserCacheEntry, 0, len(r.entries))
+	for _, e := range r.entries {
+		entries = append(entries, e)
+	}
+	slices.SortFunc(entries, func(a, b UserCacheEntry) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode profile cache: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".*")
+	if err != nil {
+		return fmt.Errorf("write profile cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write profile cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write profile cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), r.path); err != nil {
+		return fmt.Errorf("write profile cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package gomcsmp_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+)
+
+// stubResolver - knows a fixed set of players and counts the lookups.
+type stubResolver struct {
+	players *gomcsmp.PlayerRegistry
+	calls   int
+}
+
+func (s *stubResolver) ResolveName(ctx context.Context, name string) (gomcsmp.Player, error) {
+	s.calls++
+	if p, ok := s.players.PlayerByName(name); ok {
+		return p, nil
+	}
+	return gomcsmp.Player{}, gomcsmp.ErrProfileNotFound
+}
+
+func (s *stubResolver) ResolveID(ctx context.Context, id uuid.UUID) (gomcsmp.Player, error) {
+	s.calls++
+	if p, ok := s.players.PlayerByID(id); ok {
+		return p, nil
+	}
+	return gomcsmp.Player{}, gomcsmp.ErrProfileNotFound
+}
+
+func newStubResolver() *stubResolver {
+	return &stubResolver{players: registry(playerWithID("Steve", idSteve))}
+}
+
+func TestCachingResolverHit(t *testing.T) {
+	ctx := context.Background()
+	next := newStubResolver()
+
+	r, err := gomcsmp.NewCachingResolver(next, time.Hour, "")
+	if err != nil {
+		t.Fatalf("NewCachingResolver: %v", err)
+	}
+
+	for _, name := range []string{"Steve", "steve"} {
+		p, err := r.ResolveName(ctx, name)
+		if err != nil || p.ID == nil || *p.ID != idSteve {
+			t.Fatalf("ResolveName(%s) = %+v, %v", name, p, err)
+		}
+	}
+	if p, err := r.ResolveID(ctx, idSteve); err != nil || p.Name != "Steve" {
+		t.Fatalf("ResolveID = %+v, %v", p, err)
+	}
+	if next.calls != 1 {
+		t.Fatalf("next resolver called %d times, want 1", next.calls)
+	}
+}
+
+func TestCachingResolverMiss(t *testing.T) {
+	ctx := context.Background()
+	next := newStubResolver()
+
+	r, err := gomcsmp.NewCachingResolver(next, time.Hour, "")
+	if err != nil {
+		t.Fatalf("NewCachingResolver: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := r.ResolveName(ctx, "Notch"); !errors.Is(err, gomcsmp.ErrProfileNotFound) {
+			t.Fatalf("ResolveName(Notch) error = %v, want ErrProfileNotFound", err)
+		}
+	}
+	if _, err := r.ResolveID(ctx, idAlex); !errors.Is(err, gomcsmp.ErrProfileNotFound) {
+		t.Fatalf("ResolveID error = %v, want ErrProfileNotFound", err)
+	}
+	// unknown players are not cached, every lookup reaches the next resolver
+	if next.calls != 3 {
+		t.Fatalf("next resolver called %d times, want 3", next.calls)
+	}
+}
+
+func TestCachingResolverExpiry(t *testing.T) {
+	ctx := context.Background()
+	next := newStubResolver()
+
+	r, err := gomcsmp.NewCachingResolver(next, time.Nanosecond, "")
+	if err != nil {
+		t.Fatalf("NewCachingResolver: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := r.ResolveName(ctx, "Steve"); err != nil {
+			t.Fatalf("ResolveName: %v", err)
+		}
+	}
+	if next.calls != 2 {
+		t.Fatalf("next resolver called %d times, want 2", next.calls)
+	}
+}
+
+func TestCachingResolverPersists(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "profiles.json")
+
+	r, err := gomcsmp.NewCachingResolver(newStubResolver(), time.Hour, path)
+	if err != nil {
+		t.Fatalf("NewCachingResolver: %v", err)
+	}
+	if _, err := r.ResolveName(ctx, "Steve"); err != nil {
+		t.Fatalf("ResolveName: %v", err)
+	}
+
+	next := newStubResolver()
+	loaded, err := gomcsmp.NewCachingResolver(next, time.Hour, path)
+	if err != nil {
+		t.Fatalf("NewCachingResolver from file: %v", err)
+	}
+	if p, err := loaded.ResolveID(ctx, idSteve); err != nil || p.Name != "Steve" {
+		t.Fatalf("ResolveID = %+v, %v", p, err)
+	}
+	if next.calls != 0 {
+		t.Fatalf("next resolver called %d times, want the profile from the file", next.calls)
+	}
+}
+
+func TestCachingResolverWriteFailure(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "missing")
+	path := filepath.Join(dir, "profiles.json")
+
+	r, err := gomcsmp.NewCachingResolver(newStubResolver(), time.Hour, path)
+	if err != nil {
+		t.Fatalf("NewCachingResolver: %v", err)
+	}
+
+	p, err := r.ResolveName(ctx, "Steve")
+	if err != nil || p.ID == nil || *p.ID != idSteve {
+		t.Fatalf("ResolveName = %+v, %v, want the profile despite the failed write", p, err)
+	}
+	if r.Err() == nil {
+		t.Fatal("Err() = nil after a failed write")
+	}
+	if err := r.Flush(); err == nil {
+		t.Fatal("Flush succeeded without the directory")
+	}
+
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if err := r.Err(); err != nil {
+		t.Fatalf("Err() = %v after a successful flush", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("cache file: %v", err)
+	}
+}
@@ -0,0 +1,282 @@
+package gomcsmp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ServerProperties - the contents of a server.properties file.
+// Comments, blank lines and the order of keys are kept, lines which
+// were not changed are written back exactly as they were read.
+type ServerProperties struct {
+	lines []propertyLine
+}
+
+// propertyLine - one logical line; raw holds its text including continuations.
+type propertyLine struct {
+	raw      string
+	key      string
+	value    string
+	property bool
+}
+
+// NewServerProperties - creates an empty properties file.
+func NewServerProperties() *ServerProperties {
+	return &ServerProperties{}
+}
+
+// ReadServerProperties - reads properties in the java.util.Properties format.
+func ReadServerProperties(rd io.Reader) (*ServerProperties, error) {
+	p := NewServerProperties()
+	sc := bufio.NewScanner(rd)
+
+	for sc.Scan() {
+		raw := strings.TrimSuffix(sc.Text(), "\r")
+		logical := strings.TrimLeft(raw, " \t\f")
+
+		if logical == "" || logical[0] == '#' || logical[0] == '!' {
+			p.lines = append(p.lines, propertyLine{raw: raw})
+			continue
+		}
+
+		for continuesLine(logical) && sc.Scan() {
+			next := strings.TrimSuffix(sc.Text(), "\r")
+			raw += "\n" + next
+			logical = logical[:len(logical)-1] + strings.TrimLeft(next, " \t\f")
+		}
+
+		key, value, err := parsePropertyLine(logical)
+		if err != nil {
+			return nil, fmt.Errorf("read server properties: %w", err)
+		}
+		p.lines = append(p.lines, propertyLine{raw: raw, key: key, value: value, property: true})
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read server properties: %w", err)
+	}
+	return p, nil
+}
+
+// LoadServerProperties - reads a server.properties file.
+func LoadServerProperties(path string) (*ServerProperties, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open server properties: %w", err)
+	}
+	defer f.Close()
+
+	return ReadServerProperties(f)
+}
+
+// Write - writes the properties, one line per entry.
+func (p *ServerProperties) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, l := range p.lines {
+		bw.WriteString(l.raw)
+		bw.WriteByte('\n')
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write server properties: %w", err)
+	}
+	return nil
+}
+
+// Save - writes the properties to a file, replacing it.
+func (p *ServerProperties) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create server properties: %w", err)
+	}
+
+	if err := p.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Get - returns the value of a key. When a key repeats, the last one wins.
+func (p *ServerProperties) Get(key string) (string, bool) {
+	i := p.find(key)
+	if i < 0 {
+		return "", false
+	}
+	return p.lines[i].value, true
+}
+
+// Set - changes the value of a key in place or appends it to the end.
+// Unchanged values leave the original line untouched.
+func (p *ServerProperties) Set(key, value string) {
+	line := propertyLine{
+		raw:      escapeProperty(key, true) + "=" + escapeProperty(value, false),
+		key:      key,
+		value:    value,
+		property: true,
+	}
+
+	i := p.find(key)
+	switch {
+	case i < 0:
+		p.lines = append(p.lines, line)
+	case p.lines[i].value != value:
+		p.lines[i] = line
+	}
+}
+
+// Delete - removes every line defining the key.
+func (p *ServerProperties) Delete(key string) {
+	kept := p.lines[:0]
+	for _, l := range p.lines {
+		if !l.property || l.key != key {
+			kept = append(kept, l)
+		}
+	}
+	p.lines = kept
+}
+
+// Keys - returns the keys in file order, without duplicates.
+func (p *ServerProperties) Keys() []string {
+	seen := make(map[string]bool, len(p.lines))
+	keys := []string{}
+	for _, l := range p.lines {
+		if l.property && !seen[l.key] {
+			seen[l.key] = true
+			keys = append(keys, l.key)
+		}
+	}
+	return keys
+}
+
+// Map - returns all key/value pairs.
+func (p *ServerProperties) Map() map[string]string {
+	m := make(map[string]string, len(p.lines))
+	for _, l := range p.lines {
+		if l.property {
+			m[l.key] = l.value
+		}
+	}
+	return m
+}
+
+func (p *ServerProperties) find(key string) int {
+	for i := len(p.lines) - 1; i >= 0; i-- {
+		if p.lines[i].property && p.lines[i].key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// ============
+
+// continuesLine - reports whether the line ends with an odd number of backslashes.
+func continuesLine(s string) bool {
+	n := 0
+	for i := len(s) - 1; i >= 0 && s[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// parsePropertyLine - splits a logical line into its unescaped key and value.
+func parsePropertyLine(s string) (key, value string, err error) {
+	end := len(s)
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.IndexByte("=: \t\f", s[i]) >= 0 {
+			end = i
+			break
+		}
+	}
+
+	rest := strings.TrimLeft(s[end:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+
+	if key, err = unescapeProperty(s[:end]); err != nil {
+		return "", "", err
+	}
+	if value, err = unescapeProperty(rest); err != nil {
+		return "", "", err
+	}
+	return key, value, nil
+}
+
+func unescapeProperty(s string) (string, error) {
+	if !strings.Contains(s, "\\") {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", fmt.Errorf("malformed \\u escape in '%s'", s)
+			}
+			r, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("malformed \\u escape in '%s'", s)
+			}
+			b.WriteRune(rune(r))
+			i += 4
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// escapeProperty - escapes a key or value the way java.util.Properties stores it.
+// Non-ASCII characters are written as UTF-8.
+func escapeProperty(s string, key bool) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '=', ':', '#', '!':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case ' ':
+			if key || i == 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
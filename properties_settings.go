@@ -0,0 +1,240 @@
+package gomcsmp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PropertyMapping - binds a server.properties key to the live setting it controls.
+type PropertyMapping struct {
+	Key     string
+	Setting SettingDescriptor
+
+	parse  func(s string) (any, error)
+	format func(v any) string
+}
+
+// Parse - converts a properties value to the setting value.
+func (m PropertyMapping) Parse(s string) (any, error) {
+	v, err := m.parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w '%s': %w", ErrInvalidSetting, m.Key, err)
+	}
+	return v, nil
+}
+
+// Format - converts a setting value to its properties form.
+func (m PropertyMapping) Format(v any) string {
+	return m.format(v)
+}
+
+// field - returns the ServerSettings field of the mapped setting.
+func (m PropertyMapping) field() settingsField {
+	f, _ := lookupSettingsField(m.Setting.Path())
+	return f
+}
+
+func newPropertyMapping[T any](key string, setting Setting[T], parse func(string) (T, error), format func(T) string) PropertyMapping {
+	return PropertyMapping{
+		Key:     key,
+		Setting: setting,
+		parse: func(s string) (any, error) {
+			return parse(s)
+		},
+		format: func(v any) string {
+			return format(v.(T))
+		},
+	}
+}
+
+func parsePropertyBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return false, fmt.Errorf("expects true or false, got '%s'", s)
+}
+
+func parsePropertyInt(s string) (int, error) {
+	return strconv.Atoi(strings.TrimSpace(s))
+}
+
+// propertyDuration - a properties value counted in the given unit.
+func propertyDuration(unit time.Duration) (func(string) (time.Duration, error), func(time.Duration) string) {
+	parse := func(s string) (time.Duration, error) {
+		n, err := parsePropertyInt(s)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * unit, nil
+	}
+	format := func(d time.Duration) string {
+		return strconv.Itoa(int(d / unit))
+	}
+	return parse, format
+}
+
+func propertyString(s string) (string, error) {
+	return s, nil
+}
+
+var propertyMappings = func() []PropertyMapping {
+	seconds, secondsText := propertyDuration(time.Second)
+	minutes, minutesText := propertyDuration(time.Minute)
+	text := func(s string) string { return s }
+
+	return []PropertyMapping{
+		newPropertyMapping("motd", SettingMotd, propertyString, text),
+		newPropertyMapping("difficulty", SettingDifficulty, ParseDifficulty, Difficulty.String),
+		newPropertyMapping("gamemode", SettingGameMode, ParseGameMode, GameMode.String),
+		newPropertyMapping("force-gamemode", SettingForceGameMode, parsePropertyBool, strconv.FormatBool),
+		newPropertyMapping("max-players", SettingMaxPlayers, parsePropertyInt, strconv.Itoa),
+		newPropertyMapping("view-distance", SettingViewDistance, parsePropertyInt, strconv.Itoa),
+		newPropertyMapping("simulation-distance", SettingSimulationDistance, parsePropertyInt, strconv.Itoa),
+		newPropertyMapping("spawn-protection", SettingSpawnProtectionRadius, parsePropertyInt, strconv.Itoa),
+		newPropertyMapping("white-list", SettingUseAllowlist, parsePropertyBool, strconv.FormatBool),
+		newPropertyMapping("enforce-whitelist", SettingEnforceAllowlist, parsePropertyBool, strconv.FormatBool),
+		newPropertyMapping("allow-flight", SettingAllowFlight, parsePropertyBool, strconv.FormatBool),
+		newPropertyMapping("accept-transfers", SettingAcceptTransfers, parsePropertyBool, strconv.FormatBool),
+		newPropertyMapping("pause-when-empty-seconds", SettingPauseWhenEmpty, seconds, secondsText),
+		newPropertyMapping("player-idle-timeout", SettingPlayerIdleTimeout, minutes, minutesText),
+		newPropertyMapping("status-heartbeat-interval", SettingStatusHeartbeatInterval, seconds, secondsText),
+		newPropertyMapping("op-permission-level", SettingOperatorUserPermissionLevel, parsePropertyInt, strconv.Itoa),
+		newPropertyMapping("hide-online-players", SettingHideOnlinePlayers, parsePropertyBool, strconv.FormatBool),
+		newPropertyMapping("enable-status", SettingStatusReplies, parsePropertyBool, strconv.FormatBool),
+		newPropertyMapping("entity-broadcast-range-percentage", SettingEntityBroadcastRange, parsePropertyInt, strconv.Itoa),
+	}
+}()
+
+// PropertyMappings - returns the server.properties keys which can be changed live.
+// player-idle-timeout is counted in minutes in the file and converted on the way.
+// Other keys, e.g. level-seed, need a restart and are not mapped.
+func PropertyMappings() []PropertyMapping {
+	out := make([]PropertyMapping, len(propertyMappings))
+	copy(out, propertyMappings)
+	return out
+}
+
+// LookupProperty - returns the mapping of a server.properties key.
+func LookupProperty(key string) (PropertyMapping, bool) {
+	for _, m := range propertyMappings {
+		if m.Key == key {
+			return m, true
+		}
+	}
+	return PropertyMapping{}, false
+}
+
+// ============
+
+// PropertyChange - a mapped key whose file value differs from the live setting.
+// From is the live value, To the value of the file.
+type PropertyChange struct {
+	Property string
+	SettingChange
+}
+
+// propertyValues - parses every mapped key present in the file.
+func propertyValues(p *ServerProperties) (map[string]any, error) {
+	var (
+		values = map[string]any{}
+		errs   []error
+	)
+
+	for _, m := range propertyMappings {
+		raw, ok := p.Get(m.Key)
+		if !ok {
+			continue
+		}
+		v, err := m.Parse(raw)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		values[m.Key] = v
+	}
+
+	return values, errors.Join(errs...)
+}
+
+// DiffProperties - compares the mapped keys of a properties file with the live settings.
+// Keys missing from the file and unmapped keys are ignored.
+func DiffProperties(ctx context.Context, api SettingsAPI, p *ServerProperties) ([]PropertyChange, error) {
+	values, err := propertyValues(p)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []PropertyChange{}
+	for _, m := range propertyMappings {
+		to, ok := values[m.Key]
+		if !ok {
+			continue
+		}
+
+		from, err := m.field().get(ctx, api)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", m.Setting.Path(), err)
+		}
+		if from == to {
+			continue
+		}
+
+		changes = append(changes, PropertyChange{
+			Property:      m.Key,
+			SettingChange: SettingChange{Setting: m.Setting.Path(), From: from, To: to},
+		})
+	}
+
+	return changes, nil
+}
+
+// ApplyProperties - sets the live settings which differ from a properties file, without a restart.
+// Nothing is applied when a value of the file cannot be parsed.
+// All changes are attempted, failed ones carry their error in the report.
+func ApplyProperties(ctx context.Context, api SettingsAPI, p *ServerProperties) ([]PropertyChange, error) {
+	changes, err := DiffProperties(ctx, api, p)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for i := range changes {
+		ch := &changes[i]
+		m, _ := LookupProperty(ch.Property)
+
+		confirmed, err := m.field().set(ctx, api, ch.To)
+		if err != nil {
+			ch.Err = err
+			errs = append(errs, fmt.Errorf("failed to set %s: %w", ch.Setting, err))
+			continue
+		}
+		ch.To = confirmed
+	}
+
+	return changes, errors.Join(errs...)
+}
+
+// ExportProperties - writes the live settings into p and returns it.
+// A nil p starts a new file, otherwise other keys and comments are kept.
+func ExportProperties(ctx context.Context, api SettingsAPI, p *ServerProperties) (*ServerProperties, error) {
+	if p == nil {
+		p = NewServerProperties()
+	}
+
+	for _, m := range propertyMappings {
+		v, err := m.field().get(ctx, api)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", m.Setting.Path(), err)
+		}
+		p.Set(m.Key, m.Format(v))
+	}
+
+	return p, nil
+}
@@ -0,0 +1,157 @@
+package gomcsmp_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+	"github.com/eterline/go-mc-smp/mcsmptest"
+)
+
+const sampleProperties = `#Minecraft server properties
+#Sat Oct 18 12:00:00 UTC 2026
+motd=A Minecraft Server
+level-seed=
+
+# custom section
+max-players=20
+player-idle-timeout=5
+greeting=café \
+    au lait
+`
+
+func readProperties(t *testing.T, s string) *gomcsmp.ServerProperties {
+	t.Helper()
+	p, err := gomcsmp.ReadServerProperties(strings.NewReader(s))
+	if err != nil {
+		t.Fatalf("ReadServerProperties: %v", err)
+	}
+	return p
+}
+
+func writeProperties(t *testing.T, p *gomcsmp.ServerProperties) string {
+	t.Helper()
+	var b strings.Builder
+	if err := p.Write(&b); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	return b.String()
+}
+
+func TestPropertiesRoundTrip(t *testing.T) {
+	p := readProperties(t, sampleProperties)
+
+	if got := writeProperties(t, p); got != sampleProperties {
+		t.Fatalf("round trip changed the file:\n%s", got)
+	}
+
+	wantKeys := "motd,level-seed,max-players,player-idle-timeout,greeting"
+	if got := strings.Join(p.Keys(), ","); got != wantKeys {
+		t.Fatalf("Keys() = %s, want %s", got, wantKeys)
+	}
+	if v, _ := p.Get("greeting"); v != "café au lait" {
+		t.Fatalf("greeting = %q", v)
+	}
+
+	p.Set("max-players", "30")
+	p.Set("motd", "A Minecraft Server")
+	p.Set("online-mode", "true")
+
+	want := strings.Replace(sampleProperties, "max-players=20", "max-players=30", 1) + "online-mode=true\n"
+	if got := writeProperties(t, p); got != want {
+		t.Fatalf("after Set:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPropertyIdleTimeoutMinutes(t *testing.T) {
+	m, ok := gomcsmp.LookupProperty("player-idle-timeout")
+	if !ok {
+		t.Fatal("player-idle-timeout is not mapped")
+	}
+
+	v, err := m.Parse("5")
+	if err != nil || v != 5*time.Minute {
+		t.Fatalf("Parse(5) = %v, %v, want 5m", v, err)
+	}
+	if got := m.Format(10 * time.Minute); got != "10" {
+		t.Fatalf("Format(10m) = %s, want 10", got)
+	}
+	if _, err := m.Parse("soon"); !errors.Is(err, gomcsmp.ErrInvalidSetting) {
+		t.Fatalf("Parse(soon) error = %v, want ErrInvalidSetting", err)
+	}
+}
+
+func TestApplyProperties(t *testing.T) {
+	ctx := context.Background()
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+
+	p := readProperties(t, sampleProperties)
+
+	changes, err := gomcsmp.DiffProperties(ctx, client, p)
+	if err != nil {
+		t.Fatalf("DiffProperties: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Property != "player-idle-timeout" || changes[0].To != 5*time.Minute {
+		t.Fatalf("changes = %+v, want player-idle-timeout to 5m", changes)
+	}
+
+	if _, err := gomcsmp.ApplyProperties(ctx, client, p); err != nil {
+		t.Fatalf("ApplyProperties: %v", err)
+	}
+	// five minutes in the file, seconds on the wire
+	if v := srv.State().Settings["player_idle_timeout"]; v != 300 {
+		t.Fatalf("server value = %v, want 300", v)
+	}
+
+	changes, err = gomcsmp.DiffProperties(ctx, client, p)
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("after apply changes = %+v, %v, want none", changes, err)
+	}
+}
+
+func TestApplyPropertiesInvalid(t *testing.T) {
+	ctx := context.Background()
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+
+	p := readProperties(t, "max-players=many\nplayer-idle-timeout=5\n")
+
+	if _, err := gomcsmp.ApplyProperties(ctx, client, p); !errors.Is(err, gomcsmp.ErrInvalidSetting) {
+		t.Fatalf("error = %v, want ErrInvalidSetting", err)
+	}
+	if n := srv.CallCount(gomcsmp.SettingPlayerIdleTimeout.SetMethod()); n != 0 {
+		t.Fatalf("applied %d settings from an invalid file", n)
+	}
+}
+
+func TestExportProperties(t *testing.T) {
+	ctx := context.Background()
+	srv := mcsmptest.Start(t)
+	srv.Update(func(st *mcsmptest.State) {
+		st.Settings["player_idle_timeout"] = 600
+	})
+	client := srv.TestClient(t)
+
+	p, err := gomcsmp.ExportProperties(ctx, client, readProperties(t, sampleProperties))
+	if err != nil {
+		t.Fatalf("ExportProperties: %v", err)
+	}
+
+	if v, _ := p.Get("player-idle-timeout"); v != "10" {
+		t.Fatalf("player-idle-timeout = %s, want 10", v)
+	}
+	out := writeProperties(t, p)
+	if !strings.HasPrefix(out, "#Minecraft server properties\n") || !strings.Contains(out, "# custom section\n") {
+		t.Fatalf("comments were lost:\n%s", out)
+	}
+	if v, ok := p.Get("level-seed"); !ok || v != "" {
+		t.Fatalf("unmapped key level-seed = %q, %v", v, ok)
+	}
+	if keys := p.Keys(); keys[0] != "motd" || keys[1] != "level-seed" {
+		t.Fatalf("key order changed: %v", keys)
+	}
+}
@@ -0,0 +1,168 @@
+package gomcsmp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// FrameDirection - the direction of a recorded frame.
+type FrameDirection string
+
+const (
+	// FrameSession - the session header written once per connection.
+	FrameSession FrameDirection = "session"
+	// FrameOut - a request sent by the client.
+	FrameOut FrameDirection = "out"
+	// FrameIn - a response or notification received by the client.
+	FrameIn FrameDirection = "in"
+)
+
+// redacted - replaces the RPC token in recordings.
+const redacted = "[REDACTED]"
+
+// RecordedFrame - one line of a recording.
+// Frame holds the raw JSON frame, Raw holds frames which are not valid JSON.
+type RecordedFrame struct {
+	Time      time.Time         `json:"time"`
+	Direction FrameDirection    `json:"dir"`
+	Frame     json.RawMessage   `json:"frame,omitempty"`
+	Raw       string            `json:"raw,omitempty"`
+	URL       string            `json:"url,omitempty"`
+	Header    map[string]string `json:"header,omitempty"`
+}
+
+// ================
+
+// Recorder - writes the wire traffic of a client as JSON lines.
+// The RPC token never appears in the output.
+type Recorder struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+	err    error
+}
+
+// NewRecorder - creates a Recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{
+		enc: json.NewEncoder(w),
+	}
+}
+
+// CreateRecorder - creates a Recorder writing to a new file at path.
+func CreateRecorder(path string) (*Recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("create recording: %w", err)
+	}
+
+	r := NewRecorder(f)
+	r.closer = f
+	return r, nil
+}
+
+// WithRecorder - records every frame sent and received by the client.
+func WithRecorder(r *Recorder) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.recorder = r
+	}
+}
+
+// Err - returns the first error met while writing the recording.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Close - closes the underlying file, if any.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closer == nil {
+		return r.err
+	}
+	return errors.Join(r.err, r.closer.Close())
+}
+
+func (r *Recorder) write(f RecordedFrame) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return
+	}
+
+	r.err = r.enc.Encode(f)
+}
+
+func (r *Recorder) session(url string) {
+	r.write(RecordedFrame{
+		Time:      time.Now(),
+		Direction: FrameSession,
+		URL:       url,
+		Header:    map[string]string{"Authorization": "Bearer " + redacted},
+	})
+}
+
+func (r *Recorder) observer(token string) func(out bool, frame []byte) {
+	return func(out bool, frame []byte) {
+		if token != "" {
+			frame = bytes.ReplaceAll(frame, []byte(token), []byte(redacted))
+		}
+
+		f := RecordedFrame{
+			Time:      time.Now(),
+			Direction: FrameIn,
+		}
+		if out {
+			f.Direction = FrameOut
+		}
+
+		if json.Valid(frame) {
+			f.Frame = append(json.RawMessage(nil), frame...)
+		} else {
+			f.Raw = string(frame)
+		}
+
+		r.write(f)
+	}
+}
+
+// ================
+
+// ReadRecording - reads all frames of a recording.
+func ReadRecording(rd io.Reader) ([]RecordedFrame, error) {
+	dec := json.NewDecoder(rd)
+	frames := []RecordedFrame{}
+
+	for {
+		var f RecordedFrame
+		err := dec.Decode(&f)
+		if errors.Is(err, io.EOF) {
+			return frames, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read recording frame %d: %w", len(frames)+1, err)
+		}
+		frames = append(frames, f)
+	}
+}
+
+// LoadRecording - reads all frames of a recording file.
+func LoadRecording(path string) ([]RecordedFrame, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open recording: %w", err)
+	}
+	defer f.Close()
+
+	return ReadRecording(f)
+}
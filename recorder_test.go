@@ -0,0 +1,170 @@
+package gomcsmp_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+	"github.com/eterline/go-mc-smp/mcsmptest"
+)
+
+// record - runs calls against a fake server with a recorder attached and returns the recording file.
+func record(t *testing.T, calls func(client *gomcsmp.RPCClient)) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	rec, err := gomcsmp.CreateRecorder(path)
+	if err != nil {
+		t.Fatalf("CreateRecorder: %v", err)
+	}
+
+	srv := mcsmptest.Start(t)
+	client, err := srv.Client(gomcsmp.WithRecorder(rec))
+	if err != nil {
+		t.Fatalf("dial fake server: %v", err)
+	}
+
+	calls(client)
+
+	client.Close()
+	if err := rec.Close(); err != nil {
+		t.Fatalf("recorder: %v", err)
+	}
+	return path
+}
+
+func replay(t *testing.T, path string) *gomcsmp.RPCClient {
+	t.Helper()
+
+	frames, err := gomcsmp.LoadRecording(path)
+	if err != nil {
+		t.Fatalf("LoadRecording: %v", err)
+	}
+
+	client, err := gomcsmp.NewReplayClient(frames)
+	if err != nil {
+		t.Fatalf("NewReplayClient: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestRecordReplayRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	steve := gomcsmp.NewPlayer("Steve")
+
+	var live *gomcsmp.PlayerRegistry
+	path := record(t, func(client *gomcsmp.RPCClient) {
+		if err := client.AllowlistAdd(ctx, steve); err != nil {
+			t.Fatalf("AllowlistAdd: %v", err)
+		}
+		var err error
+		if live, err = client.AllowlistGet(ctx); err != nil {
+			t.Fatalf("AllowlistGet: %v", err)
+		}
+	})
+
+	client := replay(t, path)
+
+	if err := client.AllowlistAdd(ctx, steve); err != nil {
+		t.Fatalf("replayed AllowlistAdd: %v", err)
+	}
+	got, err := client.AllowlistGet(ctx)
+	if err != nil {
+		t.Fatalf("replayed AllowlistGet: %v", err)
+	}
+	if !reflect.DeepEqual(got.Players(), live.Players()) {
+		t.Fatalf("replayed allowlist = %+v, want %+v", got.Players(), live.Players())
+	}
+}
+
+func TestRecorderRedactsToken(t *testing.T) {
+	ctx := context.Background()
+
+	path := record(t, func(client *gomcsmp.RPCClient) {
+		msg := gomcsmp.SystemMessage{Message: gomcsmp.NewMessage("token is "+mcsmptest.DefaultToken, "")}
+		if _, err := client.ServerSystemMessage(ctx, msg); err != nil {
+			t.Fatalf("ServerSystemMessage: %v", err)
+		}
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte(mcsmptest.DefaultToken)) {
+		t.Fatalf("recording contains the token:\n%s", data)
+	}
+
+	frames, err := gomcsmp.ReadRecording(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadRecording: %v", err)
+	}
+	if len(frames) == 0 || frames[0].Direction != gomcsmp.FrameSession {
+		t.Fatalf("first frame = %+v, want the session header", frames)
+	}
+	if got := frames[0].Header["Authorization"]; got != "Bearer [REDACTED]" {
+		t.Fatalf("session Authorization = %q, want the redacted token", got)
+	}
+}
+
+func TestReplayMismatch(t *testing.T) {
+	ctx := context.Background()
+
+	path := record(t, func(client *gomcsmp.RPCClient) {
+		if err := client.AllowlistAdd(ctx, gomcsmp.NewPlayer("Steve")); err != nil {
+			t.Fatalf("AllowlistAdd: %v", err)
+		}
+	})
+
+	tests := []struct {
+		name string
+		call func(client *gomcsmp.RPCClient) error
+	}{
+		{"other method", func(client *gomcsmp.RPCClient) error {
+			_, err := client.BansGet(ctx)
+			return err
+		}},
+		{"other params", func(client *gomcsmp.RPCClient) error {
+			return client.AllowlistAdd(ctx, gomcsmp.NewPlayer("Alex"))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := replay(t, path)
+
+			if err := tt.call(client); !errors.Is(err, gomcsmp.ErrReplayMismatch) {
+				t.Fatalf("error = %v, want ErrReplayMismatch", err)
+			}
+			// a mismatch does not consume the recorded request
+			if err := client.AllowlistAdd(ctx, gomcsmp.NewPlayer("Steve")); err != nil {
+				t.Fatalf("recorded AllowlistAdd after mismatch: %v", err)
+			}
+		})
+	}
+}
+
+func TestReplayExhausted(t *testing.T) {
+	ctx := context.Background()
+
+	path := record(t, func(client *gomcsmp.RPCClient) {
+		if _, err := client.AllowlistGet(ctx); err != nil {
+			t.Fatalf("AllowlistGet: %v", err)
+		}
+	})
+
+	client := replay(t, path)
+
+	if _, err := client.AllowlistGet(ctx); err != nil {
+		t.Fatalf("replayed AllowlistGet: %v", err)
+	}
+	if _, err := client.AllowlistGet(ctx); !errors.Is(err, gomcsmp.ErrReplayExhausted) {
+		t.Fatalf("second AllowlistGet error = %v, want ErrReplayExhausted", err)
+	}
+}
@@ -0,0 +1,206 @@
+package gomcsmp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/eterline/go-mc-smp/internal/jsonrpc"
+)
+
+var (
+	// ErrReplayMismatch - the client made a different call than the recording expects next.
+	ErrReplayMismatch = errors.New("replay: unexpected call")
+	// ErrReplayExhausted - the client called a method after all recorded requests were replayed.
+	ErrReplayExhausted = errors.New("replay: recording exhausted")
+	// ErrReplayClosed - the replay client was closed.
+	ErrReplayClosed = errors.New("replay: closed")
+)
+
+// NewReplayClient - creates a client which serves calls from a recorded session instead of a server.
+//
+// Calls must be made in the recorded order: each call is matched by method and params against
+// the next recorded request and answered with the response recorded for it. Params are compared
+// as JSON values, so params which change between runs, e.g. ban expiry times, do not replay.
+// Recorded notifications are delivered as the replay passes them.
+// Requests which got no response in the recording fail as timed out calls.
+func NewReplayClient(frames []RecordedFrame, opts ...ClientOption) (*RPCClient, error) {
+	cfg := defaultClientConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	core, err := newReplayTransport(frames)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRPCClient(core, cfg), nil
+}
+
+// ================
+
+type replayFrame struct {
+	out  bool
+	req  *jsonrpc.RPCRequest
+	resp *jsonrpc.RPCResponse
+	used bool
+}
+
+type replayTransport struct {
+	mu            sync.Mutex
+	frames        []*replayFrame
+	cursor        int
+	nextID        int
+	closed        bool
+	notifications chan *jsonrpc.RPCResponse
+}
+
+func newReplayTransport(recorded []RecordedFrame) (*replayTransport, error) {
+	t := &replayTransport{}
+	notifications := 0
+
+	for i, f := range recorded {
+		if f.Direction == FrameSession || len(f.Frame) == 0 {
+			continue
+		}
+
+		rf := &replayFrame{out: f.Direction == FrameOut}
+
+		if rf.out {
+			var req jsonrpc.RPCRequest
+			if err := json.Unmarshal(f.Frame, &req); err != nil {
+				return nil, fmt.Errorf("replay: decode request frame %d: %w", i+1, err)
+			}
+			rf.req = &req
+		} else {
+			var resp jsonrpc.RPCResponse
+			if err := json.Unmarshal(f.Frame, &resp); err != nil {
+				return nil, fmt.Errorf("replay: decode response frame %d: %w", i+1, err)
+			}
+			rf.resp = &resp
+			if resp.ID == 0 {
+				notifications++
+			}
+		}
+
+		t.frames = append(t.frames, rf)
+	}
+
+	t.notifications = make(chan *jsonrpc.RPCResponse, notifications)
+
+	t.mu.Lock()
+	t.advance()
+	t.mu.Unlock()
+
+	return t, nil
+}
+
+// advance - moves the cursor to the next request frame,
+// delivering notifications and skipping consumed responses on the way.
+func (t *replayTransport) advance() {
+	for t.cursor < len(t.frames) {
+		f := t.frames[t.cursor]
+		if f.out {
+			return
+		}
+
+		if !f.used && f.resp.ID == 0 {
+			f.used = true
+			t.notifications <- f.resp
+		}
+		t.cursor++
+	}
+}
+
+func (t *replayTransport) CallWithContext(ctx context.Context, method string, params ...any) (*jsonrpc.RPCResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, jsonrpc.ErrContext.Wrap(err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil, ErrReplayClosed
+	}
+
+	if t.cursor >= len(t.frames) {
+		return nil, fmt.Errorf("%w: call of '%s'", ErrReplayExhausted, method)
+	}
+
+	f := t.frames[t.cursor]
+	if f.req.Method != method {
+		return nil, fmt.Errorf("%w: called '%s', recording expects '%s'", ErrReplayMismatch, method, f.req.Method)
+	}
+
+	req, err := jsonrpc.NewRPCRequest(0, method, params)
+	if err != nil {
+		return nil, jsonrpc.ErrEncodeRequest.Wrap(err)
+	}
+	if !sameParams(req.Params, f.req.Params) {
+		return nil, fmt.Errorf("%w: called '%s' with other params than recorded", ErrReplayMismatch, method)
+	}
+
+	recordedID := f.req.ID
+	t.cursor++
+
+	var resp *jsonrpc.RPCResponse
+	for _, rf := range t.frames[t.cursor:] {
+		if !rf.out && !rf.used && rf.resp.ID == recordedID {
+			rf.used = true
+			resp = rf.resp
+			break
+		}
+	}
+
+	t.advance()
+
+	if resp == nil {
+		return nil, jsonrpc.ErrContext.Wrap(context.DeadlineExceeded)
+	}
+
+	t.nextID++
+	out := *resp
+	out.ID = t.nextID
+
+	return &out, nil
+}
+
+// sameParams - reports whether two param lists hold equal JSON values.
+func sameParams(a, b []json.RawMessage) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		var va, vb any
+		if json.Unmarshal(a[i], &va) != nil || json.Unmarshal(b[i], &vb) != nil {
+			return false
+		}
+		if !reflect.DeepEqual(va, vb) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *replayTransport) Notifications() <-chan *jsonrpc.RPCResponse {
+	return t.notifications
+}
+
+func (t *replayTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil
+	}
+
+	t.closed = true
+	close(t.notifications)
+	return nil
+}
@@ -0,0 +1,116 @@
+package gomcsmp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/eterline/go-mc-smp/internal/jsonrpc"
+)
+
+// RetryPolicy - describes how failed calls of idempotent methods are repeated.
+// MaxAttempts is the total number of attempts including the first one, values below 2 disable retries.
+// The delay before attempt N+1 is InitialBackoff*Multiplier^(N-1), capped by MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+// DefaultRetryPolicy - returns a policy with 3 attempts and exponential backoff from 100ms up to 2s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+	}
+}
+
+// WithRetry - retries transient failures of methods which are safe to repeat.
+// Only getters and absolute setters are retried, never methods like ServerStop or PlayersKick.
+func WithRetry(p RetryPolicy) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.retry = p
+	}
+}
+
+func (p RetryPolicy) enabled() bool {
+	return p.MaxAttempts > 1
+}
+
+// backoff - returns the delay after the given failed attempt number.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff)
+	mult := p.Multiplier
+	if mult < 1 {
+		mult = 1
+	}
+
+	for i := 1; i < attempt; i++ {
+		d *= mult
+		if p.MaxBackoff > 0 && d >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+
+	return time.Duration(d)
+}
+
+func (p RetryPolicy) sleep(ctx context.Context, attempt int) error {
+	d := p.backoff(attempt)
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ================
+
+// RetryError - returned by calls made under a retry policy.
+// Attempts is the number of times the method was called.
+type RetryError struct {
+	Method   string
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%s failed after %d attempt(s): %v", e.Method, e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// ================
+
+// transientError - reports whether a failed call may succeed when repeated.
+// Failures caused by the caller's own context are never transient.
+// The client does not reconnect, so errors of a dropped connection
+// (EOF, reset, closed channels) are final; only calls which timed out
+// on a connection which is still alive are retried.
+func transientError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || errors.Is(err, jsonrpc.ErrConnClosed) {
+		return false
+	}
+
+	if errors.Is(err, jsonrpc.ErrContext) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
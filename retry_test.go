@@ -0,0 +1,114 @@
+package gomcsmp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+	"github.com/eterline/go-mc-smp/mcsmptest"
+)
+
+func testRetryPolicy() gomcsmp.RetryPolicy {
+	return gomcsmp.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Multiplier:     2,
+	}
+}
+
+func TestRetryTimedOutCall(t *testing.T) {
+	const method = "minecraft:players"
+
+	plan := mcsmptest.NewFaultPlan(mcsmptest.Fault{Method: method, Drop: true, Times: 1})
+	srv := mcsmptest.Start(t, mcsmptest.WithFaultPlan(plan))
+	client := srv.TestClient(t, gomcsmp.WithRetry(testRetryPolicy()), gomcsmp.WithCallTimeout(100*time.Millisecond))
+	srv.Join(gomcsmp.NewPlayer("Steve"))
+
+	online, err := client.PlayersGet(context.Background())
+	if err != nil {
+		t.Fatalf("PlayersGet: %v", err)
+	}
+	if online.Len() != 1 {
+		t.Fatalf("online = %d players, want 1", online.Len())
+	}
+	if n := srv.CallCount(method); n != 2 {
+		t.Fatalf("%s called %d times, want 2", method, n)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	const method = "minecraft:players"
+
+	plan := mcsmptest.NewFaultPlan(mcsmptest.DropResponse(method))
+	srv := mcsmptest.Start(t, mcsmptest.WithFaultPlan(plan))
+	client := srv.TestClient(t, gomcsmp.WithRetry(testRetryPolicy()), gomcsmp.WithCallTimeout(50*time.Millisecond))
+
+	if _, err := client.PlayersGet(context.Background()); err == nil {
+		t.Fatal("PlayersGet succeeded, want an error")
+	}
+	if n := srv.CallCount(method); n != 3 {
+		t.Fatalf("%s called %d times, want 3", method, n)
+	}
+}
+
+func TestRetrySkipsNonIdempotent(t *testing.T) {
+	const method = "minecraft:players/kick"
+
+	plan := mcsmptest.NewFaultPlan(mcsmptest.DropResponse(method))
+	srv := mcsmptest.Start(t, mcsmptest.WithFaultPlan(plan))
+	client := srv.TestClient(t, gomcsmp.WithRetry(testRetryPolicy()), gomcsmp.WithCallTimeout(50*time.Millisecond))
+	srv.Join(gomcsmp.NewPlayer("Steve"))
+
+	if _, err := client.PlayersKick(context.Background(), gomcsmp.NewKickPlayer("Steve", gomcsmp.Message{})); err == nil {
+		t.Fatal("PlayersKick succeeded, want an error")
+	}
+	if n := srv.CallCount(method); n != 1 {
+		t.Fatalf("%s called %d times, want 1", method, n)
+	}
+}
+
+func TestRetrySkipsClosedConnection(t *testing.T) {
+	const method = "minecraft:players"
+
+	plan := mcsmptest.NewFaultPlan(mcsmptest.DisconnectOn(method))
+	srv := mcsmptest.Start(t, mcsmptest.WithFaultPlan(plan))
+	client := srv.TestClient(t, gomcsmp.WithRetry(testRetryPolicy()), gomcsmp.WithCallTimeout(time.Second))
+
+	start := time.Now()
+	if _, err := client.PlayersGet(context.Background()); err == nil {
+		t.Fatal("PlayersGet succeeded, want an error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("PlayersGet took %s, want it to fail without waiting for the timeout", elapsed)
+	}
+	if n := srv.CallCount(method); n != 1 {
+		t.Fatalf("%s called %d times, want 1", method, n)
+	}
+
+	// the connection is gone for good, later calls fail at once
+	if _, err := client.AllowlistGet(context.Background()); err == nil {
+		t.Fatal("AllowlistGet succeeded after disconnect, want an error")
+	}
+}
+
+func TestRetryStopsOnCallerContext(t *testing.T) {
+	const method = "minecraft:players"
+
+	plan := mcsmptest.NewFaultPlan(mcsmptest.DropResponse(method))
+	srv := mcsmptest.Start(t, mcsmptest.WithFaultPlan(plan))
+	client := srv.TestClient(t, gomcsmp.WithRetry(testRetryPolicy()), gomcsmp.WithCallTimeout(time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.PlayersGet(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("PlayersGet error = %v, want context.DeadlineExceeded", err)
+	}
+	if n := srv.CallCount(method); n != 1 {
+		t.Fatalf("%s called %d times, want 1", method, n)
+	}
+}
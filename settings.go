@@ -0,0 +1,288 @@
+package gomcsmp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/eterline/go-mc-smp/internal/jsonrpc"
+	"github.com/eterline/go-mc-smp/internal/usage"
+)
+
+var ErrInvalidSetting = errors.New("invalid setting value")
+
+// Setting - a typed minecraft:serversettings entry.
+// It knows the protocol path, how values of T travel on the wire
+// and which values the client rejects before calling the server.
+type Setting[T any] struct {
+	path     string
+	subject  string
+	wire     reflect.Type
+	encode   func(T) any
+	decode   func(r *jsonrpc.RPCResponse) (T, error)
+	validate func(T) error
+}
+
+// NewSetting - describes a setting whose wire value has the Go type T.
+// validate may be nil.
+func NewSetting[T any](path, subject string, validate func(T) error) Setting[T] {
+	return NewMappedSetting(path, subject,
+		func(v T) T { return v },
+		func(v T) (T, error) { return v, nil },
+		validate,
+	)
+}
+
+// NewMappedSetting - describes a setting whose wire value of type W is converted to T,
+// e.g. a number of seconds presented as time.Duration. validate may be nil.
+func NewMappedSetting[T, W any](
+	path, subject string,
+	toWire func(T) W,
+	fromWire func(W) (T, error),
+	validate func(T) error,
+) Setting[T] {
+	return Setting[T]{
+		path:    path,
+		subject: subject,
+		wire:    typeOf[W](),
+		encode: func(v T) any {
+			return toWire(v)
+		},
+		decode: func(r *jsonrpc.RPCResponse) (T, error) {
+			data, err := jsonrpc.DecodeRPCResult[W](r)
+			if err != nil {
+				var zero T
+				return zero, err
+			}
+			return fromWire(*data)
+		},
+		validate: validate,
+	}
+}
+
+// Path - returns the path below minecraft:serversettings, e.g. "max_players".
+func (s Setting[T]) Path() string {
+	return s.path
+}
+
+// Subject - returns what the setting controls, e.g. "the maximum number of players".
+func (s Setting[T]) Subject() string {
+	return s.subject
+}
+
+// Method - returns the full name of the getter method.
+func (s Setting[T]) Method() string {
+	return usage.NewMethod(string(DomainServerSettings)).Add(s.path).String()
+}
+
+// SetMethod - returns the full name of the setter method.
+func (s Setting[T]) SetMethod() string {
+	return usage.NewMethod(string(DomainServerSettings)).Add(s.path).Add("set").String()
+}
+
+// Type - returns the Go type of the setting values.
+func (s Setting[T]) Type() reflect.Type {
+	return typeOf[T]()
+}
+
+// WireType - returns the type of the values sent over the protocol.
+func (s Setting[T]) WireType() reflect.Type {
+	return s.wire
+}
+
+// Validate - checks the value before it is sent to the server.
+func (s Setting[T]) Validate(v T) error {
+	if s.validate == nil {
+		return nil
+	}
+	if err := s.validate(v); err != nil {
+		return fmt.Errorf("%w '%s': %w", ErrInvalidSetting, s.path, err)
+	}
+	return nil
+}
+
+// Get - reads the setting.
+func (s Setting[T]) Get(ctx context.Context, rpc *RPCClient) (T, error) {
+	r, err := rpc.call(ctx, s.Method())
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return s.decode(r)
+}
+
+// Set - validates and writes the setting, returning the value confirmed by the server.
+func (s Setting[T]) Set(ctx context.Context, rpc *RPCClient, v T) (T, error) {
+	var zero T
+
+	if err := s.Validate(v); err != nil {
+		return zero, err
+	}
+
+	r, err := rpc.call(ctx, s.SetMethod(), s.encode(v))
+	if err != nil {
+		return zero, err
+	}
+	return s.decode(r)
+}
+
+// GetValue - reads the setting as an untyped value.
+func (s Setting[T]) GetValue(ctx context.Context, rpc *RPCClient) (any, error) {
+	return s.Get(ctx, rpc)
+}
+
+// SetValue - writes the setting from an untyped value, which must have the type T.
+func (s Setting[T]) SetValue(ctx context.Context, rpc *RPCClient, v any) (any, error) {
+	typed, ok := v.(T)
+	if !ok {
+		return nil, fmt.Errorf("%w '%s': expects %s, got %T", ErrInvalidSetting, s.path, s.Type(), v)
+	}
+	return s.Set(ctx, rpc, typed)
+}
+
+// ============
+
+// SettingDescriptor - the untyped view of a Setting, as listed by AllSettings.
+type SettingDescriptor interface {
+	Path() string
+	Subject() string
+	Method() string
+	SetMethod() string
+	Type() reflect.Type
+	WireType() reflect.Type
+	GetValue(ctx context.Context, rpc *RPCClient) (any, error)
+	SetValue(ctx context.Context, rpc *RPCClient, v any) (any, error)
+}
+
+// Vanilla server settings.
+var (
+	SettingAutosave = NewSetting[bool]("autosave",
+		"whether automatic world saving is enabled", nil)
+	SettingDifficulty = NewMappedSetting("difficulty",
+		"the server difficulty", Difficulty.String, ParseDifficulty, validateDifficulty)
+	SettingEnforceAllowlist = NewSetting[bool]("enforce_allowlist",
+		"whether allowlist enforcement is enabled", nil)
+	SettingUseAllowlist = NewSetting[bool]("use_allowlist",
+		"whether the allowlist is enabled", nil)
+	SettingMaxPlayers = NewSetting("max_players",
+		"the maximum number of players", nonNegative[int])
+	SettingPauseWhenEmpty = NewMappedSetting("pause_when_empty_seconds",
+		"auto-pause delay when server is empty (seconds)", durationIntSec, secondsDuration, nonNegative[time.Duration])
+	SettingPlayerIdleTimeout = NewMappedSetting("player_idle_timeout",
+		"idle player kick timeout (seconds)", durationIntSec, secondsDuration, nonNegative[time.Duration])
+	SettingAllowFlight = NewSetting[bool]("allow_flight",
+		"whether flight is allowed in Survival mode", nil)
+	SettingMotd = NewSetting[string]("motd",
+		"the server MOTD", nil)
+	SettingSpawnProtectionRadius = NewSetting("spawn_protection_radius",
+		"spawn protection radius (blocks)", nonNegative[int])
+	SettingForceGameMode = NewSetting[bool]("force_game_mode",
+		"whether default game mode is forced", nil)
+	SettingGameMode = NewMappedSetting("game_mode",
+		"the default game mode", GameMode.String, ParseGameMode, validateGameMode)
+	SettingViewDistance = NewSetting("view_distance",
+		"view distance (chunks)", nonNegative[int])
+	SettingSimulationDistance = NewSetting("simulation_distance",
+		"simulation distance (chunks)", nonNegative[int])
+	SettingAcceptTransfers = NewSetting[bool]("accept_transfers",
+		"whether player transfers are accepted", nil)
+	SettingStatusHeartbeatInterval = NewMappedSetting("status_heartbeat_interval",
+		"status heartbeat interval (seconds)", durationIntSec, secondsDuration, nonNegative[time.Duration])
+	SettingOperatorUserPermissionLevel = NewSetting("operator_user_permission_level",
+		"operator permission level", validatePermissionLevel)
+	SettingHideOnlinePlayers = NewSetting[bool]("hide_online_players",
+		"whether online players are hidden from status", nil)
+	SettingStatusReplies = NewSetting[bool]("status_replies",
+		"whether status replies are enabled", nil)
+	SettingEntityBroadcastRange = NewSetting("entity_broadcast_range",
+		"entity broadcast range (percentage)", nonNegative[int])
+)
+
+// raw string views backing SettingsDifficulty and SettingsGameMode
+var (
+	settingDifficultyName = NewSetting[string]("difficulty", "the server difficulty", nil)
+	settingGameModeName   = NewSetting[string]("game_mode", "the default game mode", nil)
+)
+
+var settingRegistry = []SettingDescriptor{
+	SettingAutosave,
+	SettingDifficulty,
+	SettingEnforceAllowlist,
+	SettingUseAllowlist,
+	SettingMaxPlayers,
+	SettingPauseWhenEmpty,
+	SettingPlayerIdleTimeout,
+	SettingAllowFlight,
+	SettingMotd,
+	SettingSpawnProtectionRadius,
+	SettingForceGameMode,
+	SettingGameMode,
+	SettingViewDistance,
+	SettingSimulationDistance,
+	SettingAcceptTransfers,
+	SettingStatusHeartbeatInterval,
+	SettingOperatorUserPermissionLevel,
+	SettingHideOnlinePlayers,
+	SettingStatusReplies,
+	SettingEntityBroadcastRange,
+}
+
+// AllSettings - returns every known server setting in protocol order.
+func AllSettings() []SettingDescriptor {
+	out := make([]SettingDescriptor, len(settingRegistry))
+	copy(out, settingRegistry)
+	return out
+}
+
+// LookupSetting - returns the setting with the given path, e.g. "max_players".
+func LookupSetting(path string) (SettingDescriptor, bool) {
+	for _, s := range settingRegistry {
+		if s.Path() == path {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// ============
+
+func intSecDuration(secPtr *int) time.Duration {
+	if secPtr == nil {
+		return 0
+	}
+	return (time.Duration(*secPtr) * time.Second)
+}
+
+func secondsDuration(sec int) (time.Duration, error) {
+	return intSecDuration(&sec), nil
+}
+
+func durationIntSec(d time.Duration) int {
+	return int(d.Seconds())
+}
+
+func nonNegative[T ~int | ~int64](v T) error {
+	if v < 0 {
+		return fmt.Errorf("must not be negative, got %v", v)
+	}
+	return nil
+}
+
+func validatePermissionLevel(level int) error {
+	if level < 0 || level > 4 {
+		return fmt.Errorf("must be in [0, 4], got %d", level)
+	}
+	return nil
+}
+
+func validateDifficulty(d Difficulty) error {
+	_, err := d.validate()
+	return err
+}
+
+func validateGameMode(m GameMode) error {
+	_, err := m.validate()
+	return err
+}
@@ -0,0 +1,257 @@
+package gomcsmp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ServerSettings - all serversettings values at once.
+// JSON uses the protocol names and values, durations are written in seconds.
+type ServerSettings struct {
+	Autosave                    bool          `json:"autosave"`
+	Difficulty                  Difficulty    `json:"difficulty"`
+	EnforceAllowlist            bool          `json:"enforce_allowlist"`
+	UseAllowlist                bool          `json:"use_allowlist"`
+	MaxPlayers                  int           `json:"max_players"`
+	PauseWhenEmpty              time.Duration `json:"-"`
+	PlayerIdleTimeout           time.Duration `json:"-"`
+	AllowFlight                 bool          `json:"allow_flight"`
+	Motd                        string        `json:"motd"`
+	SpawnProtectionRadius       int           `json:"spawn_protection_radius"`
+	ForceGameMode               bool          `json:"force_game_mode"`
+	GameMode                    GameMode      `json:"game_mode"`
+	ViewDistance                int           `json:"view_distance"`
+	SimulationDistance          int           `json:"simulation_distance"`
+	AcceptTransfers             bool          `json:"accept_transfers"`
+	StatusHeartbeatInterval     time.Duration `json:"-"`
+	OperatorUserPermissionLevel int           `json:"operator_user_permission_level"`
+	HideOnlinePlayers           bool          `json:"hide_online_players"`
+	StatusReplies               bool          `json:"status_replies"`
+	EntityBroadcastRange        int           `json:"entity_broadcast_range"`
+}
+
+type serverSettingsAlias ServerSettings
+
+type serverSettingsJSON struct {
+	serverSettingsAlias
+	PauseWhenEmptySeconds   int `json:"pause_when_empty_seconds"`
+	PlayerIdleTimeout       int `json:"player_idle_timeout"`
+	StatusHeartbeatInterval int `json:"status_heartbeat_interval"`
+}
+
+func (s ServerSettings) MarshalJSON() ([]byte, error) {
+	return json.Marshal(serverSettingsJSON{
+		serverSettingsAlias:     serverSettingsAlias(s),
+		PauseWhenEmptySeconds:   durationIntSec(s.PauseWhenEmpty),
+		PlayerIdleTimeout:       durationIntSec(s.PlayerIdleTimeout),
+		StatusHeartbeatInterval: durationIntSec(s.StatusHeartbeatInterval),
+	})
+}
+
+func (s *ServerSettings) UnmarshalJSON(b []byte) error {
+	var v serverSettingsJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = ServerSettings(v.serverSettingsAlias)
+	s.PauseWhenEmpty = intSecDuration(&v.PauseWhenEmptySeconds)
+	s.PlayerIdleTimeout = intSecDuration(&v.PlayerIdleTimeout)
+	s.StatusHeartbeatInterval = intSecDuration(&v.StatusHeartbeatInterval)
+	return nil
+}
+
+// ============
+
+// SettingChange - one setting changed by ApplySettings.
+// To holds the value confirmed by the server, or the desired value when Err is set.
+type SettingChange struct {
+	Setting string
+	From    any
+	To      any
+	Err     error
+}
+
+// settingsField - binds a ServerSettings field to its Setting and SettingsAPI methods.
+type settingsField struct {
+	name     string
+	get      func(ctx context.Context, api SettingsAPI) (any, error)
+	set      func(ctx context.Context, api SettingsAPI, v any) (any, error)
+	fetch    func(ctx context.Context, api SettingsAPI, dst *ServerSettings) error
+	diff     func(current, desired *ServerSettings) (from, to any, changed bool)
+	validate func(desired *ServerSettings) error
+	apply    func(ctx context.Context, api SettingsAPI, desired *ServerSettings) (any, error)
+}
+
+func newSettingsField[T comparable](
+	setting Setting[T],
+	get func(SettingsAPI, context.Context) (T, error),
+	set func(SettingsAPI, context.Context, T) (T, error),
+	field func(*ServerSettings) *T,
+) settingsField {
+	name := setting.Path()
+	return settingsField{
+		name: name,
+		get: func(ctx context.Context, api SettingsAPI) (any, error) {
+			return get(api, ctx)
+		},
+		set: func(ctx context.Context, api SettingsAPI, v any) (any, error) {
+			typed, ok := v.(T)
+			if !ok {
+				return nil, fmt.Errorf("%w '%s': expects %s, got %T", ErrInvalidSetting, name, setting.Type(), v)
+			}
+			return set(api, ctx, typed)
+		},
+		fetch: func(ctx context.Context, api SettingsAPI, dst *ServerSettings) error {
+			v, err := get(api, ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get %s: %w", name, err)
+			}
+			*field(dst) = v
+			return nil
+		},
+		diff: func(current, desired *ServerSettings) (any, any, bool) {
+			from, to := *field(current), *field(desired)
+			return from, to, from != to
+		},
+		validate: func(desired *ServerSettings) error {
+			return setting.Validate(*field(desired))
+		},
+		apply: func(ctx context.Context, api SettingsAPI, desired *ServerSettings) (any, error) {
+			return set(api, ctx, *field(desired))
+		},
+	}
+}
+
+var serverSettingsFields = []settingsField{
+	newSettingsField(SettingAutosave, SettingsAPI.SettingsAutosave, SettingsAPI.SettingsAutosaveSet,
+		func(s *ServerSettings) *bool { return &s.Autosave }),
+	newSettingsField(SettingDifficulty, SettingsAPI.SettingsDifficultyLevel, SettingsAPI.SettingsDifficultyLevelSet,
+		func(s *ServerSettings) *Difficulty { return &s.Difficulty }),
+	newSettingsField(SettingEnforceAllowlist, SettingsAPI.SettingsEnforceAllowlist, SettingsAPI.SettingsEnforceAllowlistSet,
+		func(s *ServerSettings) *bool { return &s.EnforceAllowlist }),
+	newSettingsField(SettingUseAllowlist, SettingsAPI.SettingsUseAllowlist, SettingsAPI.SettingsUseAllowlistSet,
+		func(s *ServerSettings) *bool { return &s.UseAllowlist }),
+	newSettingsField(SettingMaxPlayers, SettingsAPI.SettingsMaxPlayers, SettingsAPI.SettingsMaxPlayersSet,
+		func(s *ServerSettings) *int { return &s.MaxPlayers }),
+	newSettingsField(SettingPauseWhenEmpty, SettingsAPI.SettingsPauseWhenEmptySeconds, SettingsAPI.SettingsPauseWhenEmptySecondsSet,
+		func(s *ServerSettings) *time.Duration { return &s.PauseWhenEmpty }),
+	newSettingsField(SettingPlayerIdleTimeout, SettingsAPI.SettingsPlayerIdleTimeout, SettingsAPI.SettingsPlayerIdleTimeoutSet,
+		func(s *ServerSettings) *time.Duration { return &s.PlayerIdleTimeout }),
+	newSettingsField(SettingAllowFlight, SettingsAPI.SettingsAllowFlight, SettingsAPI.SettingsAllowFlightSet,
+		func(s *ServerSettings) *bool { return &s.AllowFlight }),
+	newSettingsField(SettingMotd, SettingsAPI.SettingsMotd, SettingsAPI.SettingsMotdSet,
+		func(s *ServerSettings) *string { return &s.Motd }),
+	newSettingsField(SettingSpawnProtectionRadius, SettingsAPI.SettingsSpawnProtectionRadius, SettingsAPI.SettingsSpawnProtectionRadiusSet,
+		func(s *ServerSettings) *int { return &s.SpawnProtectionRadius }),
+	newSettingsField(SettingForceGameMode, SettingsAPI.SettingsForceGameMode, SettingsAPI.SettingsForceGameModeSet,
+		func(s *ServerSettings) *bool { return &s.ForceGameMode }),
+	newSettingsField(SettingGameMode, SettingsAPI.SettingsDefaultGameMode, SettingsAPI.SettingsDefaultGameModeSet,
+		func(s *ServerSettings) *GameMode { return &s.GameMode }),
+	newSettingsField(SettingViewDistance, SettingsAPI.SettingsViewDistance, SettingsAPI.SettingsViewDistanceSet,
+		func(s *ServerSettings) *int { return &s.ViewDistance }),
+	newSettingsField(SettingSimulationDistance, SettingsAPI.SettingsSimulationDistance, SettingsAPI.SettingsSimulationDistanceSet,
+		func(s *ServerSettings) *int { return &s.SimulationDistance }),
+	newSettingsField(SettingAcceptTransfers, SettingsAPI.SettingsAcceptTransfers, SettingsAPI.SettingsAcceptTransfersSet,
+		func(s *ServerSettings) *bool { return &s.AcceptTransfers }),
+	newSettingsField(SettingStatusHeartbeatInterval, SettingsAPI.SettingsStatusHeartbeatInterval, SettingsAPI.SettingsStatusHeartbeatIntervalSet,
+		func(s *ServerSettings) *time.Duration { return &s.StatusHeartbeatInterval }),
+	newSettingsField(SettingOperatorUserPermissionLevel, SettingsAPI.SettingsOperatorUserPermissionLevel, SettingsAPI.SettingsOperatorUserPermissionLevelSet,
+		func(s *ServerSettings) *int { return &s.OperatorUserPermissionLevel }),
+	newSettingsField(SettingHideOnlinePlayers, SettingsAPI.SettingsHideOnlinePlayers, SettingsAPI.SettingsHideOnlinePlayersSet,
+		func(s *ServerSettings) *bool { return &s.HideOnlinePlayers }),
+	newSettingsField(SettingStatusReplies, SettingsAPI.SettingsStatusReplies, SettingsAPI.SettingsStatusRepliesSet,
+		func(s *ServerSettings) *bool { return &s.StatusReplies }),
+	newSettingsField(SettingEntityBroadcastRange, SettingsAPI.SettingsEntityBroadcastRange, SettingsAPI.SettingsEntityBroadcastRangeSet,
+		func(s *ServerSettings) *int { return &s.EntityBroadcastRange }),
+}
+
+// lookupSettingsField - returns the field of the setting with the given path.
+func lookupSettingsField(path string) (settingsField, bool) {
+	for _, f := range serverSettingsFields {
+		if f.name == path {
+			return f, true
+		}
+	}
+	return settingsField{}, false
+}
+
+// ============
+
+// SnapshotSettings - fetches every setting concurrently.
+// Settings which could not be read are left zero and reported in the joined error.
+func SnapshotSettings(ctx context.Context, api SettingsAPI) (*ServerSettings, error) {
+	var (
+		s    ServerSettings
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	for _, f := range serverSettingsFields {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := f.fetch(ctx, api, &s); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return &s, errors.Join(errs...)
+}
+
+// ApplySettings - sets the settings whose current value differs from desired.
+// desired holds every setting, so it is usually a modified SnapshotSettings result:
+// zero fields are applied as zero values. Nothing is sent when a value is invalid,
+// which catches a partly filled ServerSettings by its empty difficulty and game mode.
+// All changes are attempted, failed ones carry their error in the report.
+func ApplySettings(ctx context.Context, api SettingsAPI, desired ServerSettings) ([]SettingChange, error) {
+	var invalid []error
+	for _, f := range serverSettingsFields {
+		if err := f.validate(&desired); err != nil {
+			invalid = append(invalid, err)
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, errors.Join(invalid...)
+	}
+
+	current, err := SnapshotSettings(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		changes = []SettingChange{}
+		errs    []error
+	)
+
+	for _, f := range serverSettingsFields {
+		from, to, changed := f.diff(current, &desired)
+		if !changed {
+			continue
+		}
+
+		ch := SettingChange{Setting: f.name, From: from, To: to}
+
+		confirmed, err := f.apply(ctx, api, &desired)
+		if err != nil {
+			ch.Err = err
+			errs = append(errs, fmt.Errorf("failed to set %s: %w", f.name, err))
+		} else {
+			ch.To = confirmed
+		}
+
+		changes = append(changes, ch)
+	}
+
+	return changes, errors.Join(errs...)
+}
@@ -0,0 +1,157 @@
+package gomcsmp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+	"github.com/eterline/go-mc-smp/mcsmptest"
+)
+
+// setCalls - returns the serversettings setters called so far.
+func setCalls(srv *mcsmptest.Server) []string {
+	calls := []string{}
+	for _, m := range srv.Calls() {
+		if strings.HasPrefix(m, "minecraft:serversettings/") && strings.HasSuffix(m, "/set") {
+			calls = append(calls, m)
+		}
+	}
+	return calls
+}
+
+func TestSnapshotSettings(t *testing.T) {
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+
+	s, err := gomcsmp.SnapshotSettings(context.Background(), client)
+	if err != nil {
+		t.Fatalf("SnapshotSettings: %v", err)
+	}
+
+	st := srv.State().Settings
+	if s.MaxPlayers != st["max_players"] || s.Motd != st["motd"] || s.Difficulty.String() != st["difficulty"] {
+		t.Fatalf("snapshot = %+v, want the server settings %v", s, st)
+	}
+	if want := time.Duration(st["pause_when_empty_seconds"].(int)) * time.Second; s.PauseWhenEmpty != want {
+		t.Fatalf("PauseWhenEmpty = %v, want %v", s.PauseWhenEmpty, want)
+	}
+}
+
+func TestServerSettingsJSON(t *testing.T) {
+	in := gomcsmp.ServerSettings{
+		Difficulty:     gomcsmp.DifficultyHard,
+		GameMode:       gomcsmp.GameModeCreative,
+		MaxPlayers:     50,
+		PauseWhenEmpty: 90 * time.Second,
+	}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(b), `"pause_when_empty_seconds":90`) {
+		t.Fatalf("Marshal = %s, want the duration in seconds", b)
+	}
+
+	var out gomcsmp.ServerSettings
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", b, err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("round trip = %+v, want %+v", out, in)
+	}
+}
+
+func TestApplySettings(t *testing.T) {
+	ctx := context.Background()
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+
+	desired, err := gomcsmp.SnapshotSettings(ctx, client)
+	if err != nil {
+		t.Fatalf("SnapshotSettings: %v", err)
+	}
+	desired.MaxPlayers = 50
+	desired.Motd = "" // zero values of a snapshot are applied like any other
+
+	changes, err := gomcsmp.ApplySettings(ctx, client, *desired)
+	if err != nil {
+		t.Fatalf("ApplySettings: %v", err)
+	}
+
+	got := map[string]any{}
+	for _, ch := range changes {
+		got[ch.Setting] = ch.To
+	}
+	if want := map[string]any{"max_players": 50, "motd": ""}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("changes = %v, want %v", got, want)
+	}
+
+	st := srv.State().Settings
+	if st["max_players"] != 50 || st["motd"] != "" {
+		t.Fatalf("server settings = %v, want max_players 50 and an empty motd", st)
+	}
+	if calls := setCalls(srv); len(calls) != 2 {
+		t.Fatalf("setters called = %v, want 2", calls)
+	}
+}
+
+func TestApplySettingsZeroFields(t *testing.T) {
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+	before := srv.State().Settings
+
+	// a partly filled struct would reset every other setting to its zero value
+	changes, err := gomcsmp.ApplySettings(context.Background(), client, gomcsmp.ServerSettings{MaxPlayers: 50})
+	if !errors.Is(err, gomcsmp.ErrInvalidSetting) {
+		t.Fatalf("ApplySettings error = %v, want ErrInvalidSetting", err)
+	}
+	if changes != nil {
+		t.Fatalf("changes = %+v, want none", changes)
+	}
+	if calls := setCalls(srv); len(calls) != 0 {
+		t.Fatalf("setters called = %v, want none", calls)
+	}
+	if after := srv.State().Settings; !reflect.DeepEqual(after, before) {
+		t.Fatalf("settings changed to %v", after)
+	}
+}
+
+func TestApplySettingsReportsFailures(t *testing.T) {
+	ctx := context.Background()
+	plan := mcsmptest.NewFaultPlan(mcsmptest.FailWith("minecraft:serversettings/motd/set", "refused"))
+	srv := mcsmptest.Start(t, mcsmptest.WithFaultPlan(plan))
+	client := srv.TestClient(t)
+
+	desired, err := gomcsmp.SnapshotSettings(ctx, client)
+	if err != nil {
+		t.Fatalf("SnapshotSettings: %v", err)
+	}
+	desired.MaxPlayers = 50
+	desired.Motd = "hello"
+
+	changes, err := gomcsmp.ApplySettings(ctx, client, *desired)
+	if err == nil {
+		t.Fatal("ApplySettings succeeded, want the motd error")
+	}
+	for _, ch := range changes {
+		switch ch.Setting {
+		case "motd":
+			if ch.Err == nil || ch.To != "hello" {
+				t.Fatalf("motd change = %+v, want the error and the desired value", ch)
+			}
+		case "max_players":
+			if ch.Err != nil || ch.To != 50 {
+				t.Fatalf("max_players change = %+v, want it applied", ch)
+			}
+		}
+	}
+	if srv.State().Settings["max_players"] != 50 {
+		t.Fatal("max_players was not applied after the motd failure")
+	}
+}
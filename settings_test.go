@@ -0,0 +1,137 @@
+package gomcsmp_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+	"github.com/eterline/go-mc-smp/mcsmptest"
+)
+
+func TestSettingDescriptor(t *testing.T) {
+	s := gomcsmp.SettingPauseWhenEmpty
+
+	if got, want := s.Method(), "minecraft:serversettings/pause_when_empty_seconds"; got != want {
+		t.Fatalf("Method() = %q, want %q", got, want)
+	}
+	if got, want := s.SetMethod(), "minecraft:serversettings/pause_when_empty_seconds/set"; got != want {
+		t.Fatalf("SetMethod() = %q, want %q", got, want)
+	}
+	if s.Type() != reflect.TypeOf(time.Duration(0)) || s.WireType() != reflect.TypeOf(0) {
+		t.Fatalf("types = %v over %v, want time.Duration over int", s.Type(), s.WireType())
+	}
+}
+
+func TestSettingValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"max players", gomcsmp.SettingMaxPlayers.Validate(-1)},
+		{"duration", gomcsmp.SettingPlayerIdleTimeout.Validate(-time.Second)},
+		{"permission level", gomcsmp.SettingOperatorUserPermissionLevel.Validate(5)},
+		{"difficulty", gomcsmp.SettingDifficulty.Validate(gomcsmp.Difficulty("nightmare"))},
+		{"game mode", gomcsmp.SettingGameMode.Validate(gomcsmp.GameMode(""))},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, gomcsmp.ErrInvalidSetting) {
+			t.Errorf("%s: error = %v, want ErrInvalidSetting", tt.name, tt.err)
+		}
+	}
+
+	for name, err := range map[string]error{
+		"max players":      gomcsmp.SettingMaxPlayers.Validate(0),
+		"permission level": gomcsmp.SettingOperatorUserPermissionLevel.Validate(4),
+		"motd":             gomcsmp.SettingMotd.Validate(""),
+	} {
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestSettingGetSet(t *testing.T) {
+	ctx := context.Background()
+	srv := mcsmptest.Start(t)
+	client := srv.TestClient(t)
+
+	got, err := gomcsmp.SettingPlayerIdleTimeout.Set(ctx, client, 90*time.Second)
+	if err != nil || got != 90*time.Second {
+		t.Fatalf("Set = %v, %v, want 1m30s", got, err)
+	}
+	// durations travel as whole seconds
+	if v := srv.State().Settings["player_idle_timeout"]; v != 90 {
+		t.Fatalf("server value = %v, want 90", v)
+	}
+	if got, err := gomcsmp.SettingPlayerIdleTimeout.Get(ctx, client); err != nil || got != 90*time.Second {
+		t.Fatalf("Get = %v, %v, want 1m30s", got, err)
+	}
+
+	if _, err := gomcsmp.SettingMaxPlayers.Set(ctx, client, -1); !errors.Is(err, gomcsmp.ErrInvalidSetting) {
+		t.Fatalf("Set(-1) error = %v, want ErrInvalidSetting", err)
+	}
+	if n := srv.CallCount(gomcsmp.SettingMaxPlayers.SetMethod()); n != 0 {
+		t.Fatalf("invalid value sent %d times", n)
+	}
+
+	if got, err := gomcsmp.SettingDifficulty.Set(ctx, client, gomcsmp.DifficultyHard); err != nil || got != gomcsmp.DifficultyHard {
+		t.Fatalf("Set(hard) = %v, %v", got, err)
+	}
+	if v := srv.State().Settings["difficulty"]; v != "hard" {
+		t.Fatalf("server difficulty = %v, want hard", v)
+	}
+}
+
+func TestSettingUntypedValues(t *testing.T) {
+	ctx := context.Background()
+	client := mcsmptest.Start(t).TestClient(t)
+
+	s, ok := gomcsmp.LookupSetting("max_players")
+	if !ok {
+		t.Fatal("max_players is not registered")
+	}
+
+	if v, err := s.SetValue(ctx, client, 12); err != nil || v != 12 {
+		t.Fatalf("SetValue(12) = %v, %v", v, err)
+	}
+	if v, err := s.GetValue(ctx, client); err != nil || v != 12 {
+		t.Fatalf("GetValue = %v, %v, want 12", v, err)
+	}
+	if _, err := s.SetValue(ctx, client, "12"); !errors.Is(err, gomcsmp.ErrInvalidSetting) {
+		t.Fatalf("SetValue(string) error = %v, want ErrInvalidSetting", err)
+	}
+}
+
+func TestAllSettings(t *testing.T) {
+	all := gomcsmp.AllSettings()
+	if len(all) == 0 {
+		t.Fatal("no settings registered")
+	}
+
+	seen := map[string]bool{}
+	for _, s := range all {
+		if seen[s.Path()] {
+			t.Errorf("%s registered twice", s.Path())
+		}
+		seen[s.Path()] = true
+
+		if found, ok := gomcsmp.LookupSetting(s.Path()); !ok || found.Method() != s.Method() {
+			t.Errorf("LookupSetting(%s) = %v, %v", s.Path(), found, ok)
+		}
+		if s.Subject() == "" {
+			t.Errorf("%s has no subject", s.Path())
+		}
+	}
+
+	// the returned slice is a copy
+	all[0] = nil
+	if gomcsmp.AllSettings()[0] == nil {
+		t.Fatal("AllSettings exposes the registry")
+	}
+	if _, ok := gomcsmp.LookupSetting("level_seed"); ok {
+		t.Fatal("LookupSetting found an unknown path")
+	}
+}
@@ -0,0 +1,456 @@
+package gomcsmp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidComponent = errors.New("invalid text component")
+
+// maxComponentDepth - nesting limit accepted by Validate, the same as vanilla.
+const maxComponentDepth = 512
+
+// ============
+
+// TextColor - a named text color or a "#RRGGBB" hex color.
+type TextColor string
+
+const (
+	ColorBlack       TextColor = "black"
+	ColorDarkBlue    TextColor = "dark_blue"
+	ColorDarkGreen   TextColor = "dark_green"
+	ColorDarkAqua    TextColor = "dark_aqua"
+	ColorDarkRed     TextColor = "dark_red"
+	ColorDarkPurple  TextColor = "dark_purple"
+	ColorGold        TextColor = "gold"
+	ColorGray        TextColor = "gray"
+	ColorDarkGray    TextColor = "dark_gray"
+	ColorBlue        TextColor = "blue"
+	ColorGreen       TextColor = "green"
+	ColorAqua        TextColor = "aqua"
+	ColorRed         TextColor = "red"
+	ColorLightPurple TextColor = "light_purple"
+	ColorYellow      TextColor = "yellow"
+	ColorWhite       TextColor = "white"
+)
+
+// NamedColors - returns the 16 named colors ordered by their legacy formatting code 0-f.
+func NamedColors() []TextColor {
+	return []TextColor{
+		ColorBlack, ColorDarkBlue, ColorDarkGreen, ColorDarkAqua,
+		ColorDarkRed, ColorDarkPurple, ColorGold, ColorGray,
+		ColorDarkGray, ColorBlue, ColorGreen, ColorAqua,
+		ColorRed, ColorLightPurple, ColorYellow, ColorWhite,
+	}
+}
+
+// namedColorRGB - RGB values of the named colors, in NamedColors order.
+var namedColorRGB = [16]uint32{
+	0x000000, 0x0000AA, 0x00AA00, 0x00AAAA,
+	0xAA0000, 0xAA00AA, 0xFFAA00, 0xAAAAAA,
+	0x555555, 0x5555FF, 0x55FF55, 0x55FFFF,
+	0xFF5555, 0xFF55FF, 0xFFFF55, 0xFFFFFF,
+}
+
+// HexColor - creates a hex TextColor from RGB components.
+func HexColor(r, g, b uint8) TextColor {
+	return TextColor(fmt.Sprintf("#%02X%02X%02X", r, g, b))
+}
+
+// ParseColor - parses a named or "#RRGGBB" color, ignoring case.
+func ParseColor(s string) (TextColor, error) {
+	c := TextColor(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := c.RGB(); !ok {
+		return "", fmt.Errorf("%w: unknown color '%s'", ErrInvalidComponent, s)
+	}
+	if c.IsHex() {
+		c = TextColor(strings.ToUpper(string(c)))
+	}
+	return c, nil
+}
+
+// IsHex - reports whether the color is written as "#RRGGBB".
+func (c TextColor) IsHex() bool {
+	return strings.HasPrefix(string(c), "#")
+}
+
+// RGB - returns the 24-bit RGB value of the color.
+func (c TextColor) RGB() (rgb uint32, ok bool) {
+	if c.IsHex() {
+		if len(c) != 7 {
+			return 0, false
+		}
+		v, err := strconv.ParseUint(string(c[1:]), 16, 32)
+		if err != nil {
+			return 0, false
+		}
+		return uint32(v), true
+	}
+
+	for i, named := range NamedColors() {
+		if c == named {
+			return namedColorRGB[i], true
+		}
+	}
+	return 0, false
+}
+
+// ============
+
+// ClickAction - the action of a ClickEvent.
+type ClickAction string
+
+const (
+	ClickOpenURL         ClickAction = "open_url"
+	ClickRunCommand      ClickAction = "run_command"
+	ClickSuggestCommand  ClickAction = "suggest_command"
+	ClickChangePage      ClickAction = "change_page"
+	ClickCopyToClipboard ClickAction = "copy_to_clipboard"
+)
+
+// ClickEvent - an action performed when the player clicks the text.
+type ClickEvent struct {
+	Action  ClickAction `json:"action"`
+	URL     string      `json:"url,omitempty"`
+	Command string      `json:"command,omitempty"`
+	Page    int         `json:"page,omitempty"`
+	Value   string      `json:"value,omitempty"`
+}
+
+func OpenURL(u string) *ClickEvent {
+	return &ClickEvent{Action: ClickOpenURL, URL: u}
+}
+
+func RunCommand(cmd string) *ClickEvent {
+	return &ClickEvent{Action: ClickRunCommand, Command: cmd}
+}
+
+func SuggestCommand(cmd string) *ClickEvent {
+	return &ClickEvent{Action: ClickSuggestCommand, Command: cmd}
+}
+
+func CopyToClipboard(value string) *ClickEvent {
+	return &ClickEvent{Action: ClickCopyToClipboard, Value: value}
+}
+
+func (e *ClickEvent) validate() error {
+	switch e.Action {
+	case ClickOpenURL:
+		u, err := url.Parse(e.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("%w: open_url needs an http(s) url, got '%s'", ErrInvalidComponent, e.URL)
+		}
+	case ClickRunCommand, ClickSuggestCommand:
+		if e.Command == "" {
+			return fmt.Errorf("%w: %s needs a command", ErrInvalidComponent, e.Action)
+		}
+	case ClickChangePage:
+		if e.Page < 1 {
+			return fmt.Errorf("%w: change_page needs a positive page", ErrInvalidComponent)
+		}
+	case ClickCopyToClipboard:
+	default:
+		return fmt.Errorf("%w: unknown click action '%s'", ErrInvalidComponent, e.Action)
+	}
+	return nil
+}
+
+// HoverAction - the action of a HoverEvent.
+type HoverAction string
+
+const (
+	HoverShowText   HoverAction = "show_text"
+	HoverShowItem   HoverAction = "show_item"
+	HoverShowEntity HoverAction = "show_entity"
+)
+
+// HoverEvent - a tooltip shown when the player hovers the text.
+// Value is used by show_text, ID and Count by show_item, ID, UUID and Name by show_entity.
+type HoverEvent struct {
+	Action HoverAction    `json:"action"`
+	Value  *TextComponent `json:"value,omitempty"`
+	ID     string         `json:"id,omitempty"`
+	Count  int            `json:"count,omitempty"`
+	UUID   string         `json:"uuid,omitempty"`
+	Name   *TextComponent `json:"name,omitempty"`
+}
+
+func ShowText(c TextComponent) *HoverEvent {
+	return &HoverEvent{Action: HoverShowText, Value: &c}
+}
+
+func ShowItem(id string, count int) *HoverEvent {
+	return &HoverEvent{Action: HoverShowItem, ID: id, Count: count}
+}
+
+func (e *HoverEvent) validate(depth int) error {
+	switch e.Action {
+	case HoverShowText:
+		if e.Value == nil {
+			return fmt.Errorf("%w: show_text needs a value", ErrInvalidComponent)
+		}
+		return e.Value.validate(depth + 1)
+	case HoverShowItem, HoverShowEntity:
+		if e.ID == "" {
+			return fmt.Errorf("%w: %s needs an id", ErrInvalidComponent, e.Action)
+		}
+		if e.Name != nil {
+			return e.Name.validate(depth + 1)
+		}
+	default:
+		return fmt.Errorf("%w: unknown hover action '%s'", ErrInvalidComponent, e.Action)
+	}
+	return nil
+}
+
+// ============
+
+// TextComponent - a vanilla JSON text component.
+// Style flags are tri-state: nil inherits the parent style.
+type TextComponent struct {
+	Text      string          `json:"text,omitempty"`
+	Translate string          `json:"translate,omitempty"`
+	With      []TextComponent `json:"with,omitempty"`
+	Fallback  string          `json:"fallback,omitempty"`
+
+	Color         TextColor `json:"color,omitempty"`
+	Font          string    `json:"font,omitempty"`
+	Bold          *bool     `json:"bold,omitempty"`
+	Italic        *bool     `json:"italic,omitempty"`
+	Underlined    *bool     `json:"underlined,omitempty"`
+	Strikethrough *bool     `json:"strikethrough,omitempty"`
+	Obfuscated    *bool     `json:"obfuscated,omitempty"`
+	Insertion     string    `json:"insertion,omitempty"`
+
+	ClickEvent *ClickEvent `json:"click_event,omitempty"`
+	HoverEvent *HoverEvent `json:"hover_event,omitempty"`
+
+	Extra []TextComponent `json:"extra,omitempty"`
+}
+
+type textComponentJSON TextComponent
+
+// MarshalJSON - encodes the component in the vanilla format.
+// A component without translate always carries "text", even when empty.
+func (c TextComponent) MarshalJSON() ([]byte, error) {
+	if c.Translate != "" {
+		return json.Marshal(textComponentJSON(c))
+	}
+
+	return json.Marshal(struct {
+		Text string `json:"text"`
+		textComponentJSON
+	}{c.Text, textComponentJSON(c)})
+}
+
+// UnmarshalJSON - decodes the vanilla format including the string and array shorthands.
+func (c *TextComponent) UnmarshalJSON(b []byte) error {
+	b = []byte(strings.TrimSpace(string(b)))
+	if len(b) == 0 {
+		return fmt.Errorf("%w: empty json", ErrInvalidComponent)
+	}
+
+	switch b[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*c = TextComponent{Text: s}
+		return nil
+
+	case '[':
+		var list []TextComponent
+		if err := json.Unmarshal(b, &list); err != nil {
+			return err
+		}
+		if len(list) == 0 {
+			return fmt.Errorf("%w: empty component list", ErrInvalidComponent)
+		}
+		*c = list[0]
+		c.Extra = append(c.Extra, list[1:]...)
+		return nil
+	}
+
+	var v textComponentJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*c = TextComponent(v)
+	return nil
+}
+
+// Validate - checks colors, events and nesting of the component tree.
+func (c TextComponent) Validate() error {
+	return c.validate(0)
+}
+
+func (c *TextComponent) validate(depth int) error {
+	if depth > maxComponentDepth {
+		return fmt.Errorf("%w: nesting deeper than %d", ErrInvalidComponent, maxComponentDepth)
+	}
+
+	if c.Text != "" && c.Translate != "" {
+		return fmt.Errorf("%w: both text and translate are set", ErrInvalidComponent)
+	}
+
+	if len(c.With) > 0 && c.Translate == "" {
+		return fmt.Errorf("%w: translation args without translate key", ErrInvalidComponent)
+	}
+
+	if c.Color != "" {
+		if _, ok := c.Color.RGB(); !ok {
+			return fmt.Errorf("%w: unknown color '%s'", ErrInvalidComponent, c.Color)
+		}
+	}
+
+	if c.ClickEvent != nil {
+		if err := c.ClickEvent.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.HoverEvent != nil {
+		if err := c.HoverEvent.validate(depth); err != nil {
+			return err
+		}
+	}
+
+	for i := range c.With {
+		if err := c.With[i].validate(depth + 1); err != nil {
+			return err
+		}
+	}
+
+	for i := range c.Extra {
+		if err := c.Extra[i].validate(depth + 1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PlainText - returns the text content of the tree without styles.
+// Translatable components are written as their fallback or key with arguments appended.
+func (c TextComponent) PlainText() string {
+	b := &strings.Builder{}
+	c.writePlain(b)
+	return b.String()
+}
+
+func (c *TextComponent) writePlain(b *strings.Builder) {
+	switch {
+	case c.Translate != "" && c.Fallback != "":
+		b.WriteString(c.Fallback)
+	case c.Translate != "":
+		b.WriteString(c.Translate)
+		for i := range c.With {
+			b.WriteString(" ")
+			c.With[i].writePlain(b)
+		}
+	default:
+		b.WriteString(c.Text)
+	}
+
+	for i := range c.Extra {
+		c.Extra[i].writePlain(b)
+	}
+}
+
+// ============
+
+// TextBuilder - a fluent builder of TextComponent.
+type TextBuilder struct {
+	c TextComponent
+}
+
+// NewText - starts a literal text component.
+func NewText(text string) *TextBuilder {
+	return &TextBuilder{c: TextComponent{Text: text}}
+}
+
+// NewTranslatable - starts a translatable component with the given arguments.
+func NewTranslatable(key string, args ...TextComponent) *TextBuilder {
+	return &TextBuilder{c: TextComponent{Translate: key, With: args}}
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func (b *TextBuilder) Color(c TextColor) *TextBuilder {
+	b.c.Color = c
+	return b
+}
+
+func (b *TextBuilder) Font(font string) *TextBuilder {
+	b.c.Font = font
+	return b
+}
+
+func (b *TextBuilder) Bold(v bool) *TextBuilder {
+	b.c.Bold = boolPtr(v)
+	return b
+}
+
+func (b *TextBuilder) Italic(v bool) *TextBuilder {
+	b.c.Italic = boolPtr(v)
+	return b
+}
+
+func (b *TextBuilder) Underlined(v bool) *TextBuilder {
+	b.c.Underlined = boolPtr(v)
+	return b
+}
+
+func (b *TextBuilder) Strikethrough(v bool) *TextBuilder {
+	b.c.Strikethrough = boolPtr(v)
+	return b
+}
+
+func (b *TextBuilder) Obfuscated(v bool) *TextBuilder {
+	b.c.Obfuscated = boolPtr(v)
+	return b
+}
+
+func (b *TextBuilder) Insertion(text string) *TextBuilder {
+	b.c.Insertion = text
+	return b
+}
+
+func (b *TextBuilder) Fallback(text string) *TextBuilder {
+	b.c.Fallback = text
+	return b
+}
+
+func (b *TextBuilder) Click(e *ClickEvent) *TextBuilder {
+	b.c.ClickEvent = e
+	return b
+}
+
+func (b *TextBuilder) Hover(e *HoverEvent) *TextBuilder {
+	b.c.HoverEvent = e
+	return b
+}
+
+// Append - adds children to the component. Children inherit its style.
+func (b *TextBuilder) Append(children ...TextComponent) *TextBuilder {
+	b.c.Extra = append(b.c.Extra, children...)
+	return b
+}
+
+// Build - returns the built component.
+func (b *TextBuilder) Build() TextComponent {
+	return b.c
+}
+
+// Message - returns the built component as a Message with § formatting codes,
+// see NewComponentMessage.
+func (b *TextBuilder) Message() Message {
+	return NewComponentMessage(b.c)
+}
@@ -0,0 +1,197 @@
+package gomcsmp
+
+import (
+	"strings"
+)
+
+// Legacy formatting code prefixes.
+const (
+	LegacySection   = '§'
+	LegacyAmpersand = '&'
+)
+
+const legacyColorCodes = "0123456789abcdef"
+
+// ParseLegacy - converts text with legacy formatting codes into a text component.
+//
+// Supported codes are colors 0-f, k (obfuscated), l (bold), m (strikethrough),
+// n (underlined), o (italic) and r (reset), as well as hex colors written
+// as "<p>x<p>R<p>R<p>G<p>G<p>B<p>B" or "<p>#RRGGBB". A color code resets
+// formatting, like in vanilla. Unknown codes are kept as text.
+func ParseLegacy(s string, prefix rune) TextComponent {
+	var (
+		spans []textSpan
+		style textStyle
+		text  strings.Builder
+	)
+
+	flush := func() {
+		spans = appendSpan(spans, textSpan{text: text.String(), style: style})
+		text.Reset()
+	}
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r != prefix || i+1 >= len(runes) {
+			text.WriteRune(r)
+			continue
+		}
+
+		if color, n := legacyHexColor(runes[i:], prefix); n > 0 {
+			flush()
+			style = textStyle{Color: color}
+			i += n - 1
+			continue
+		}
+
+		code := toLowerRune(runes[i+1])
+		switch {
+		case strings.ContainsRune(legacyColorCodes, code):
+			flush()
+			style = textStyle{Color: NamedColors()[strings.IndexRune(legacyColorCodes, code)]}
+		case code == 'k':
+			flush()
+			style.Obfuscated = true
+		case code == 'l':
+			flush()
+			style.Bold = true
+		case code == 'm':
+			flush()
+			style.Strikethrough = true
+		case code == 'n':
+			flush()
+			style.Underlined = true
+		case code == 'o':
+			flush()
+			style.Italic = true
+		case code == 'r':
+			flush()
+			style = textStyle{}
+		default:
+			text.WriteRune(r)
+			continue
+		}
+		i++
+	}
+
+	flush()
+	return spansComponent(spans)
+}
+
+// legacyHexColor - parses a hex color at the start of runes,
+// returning the color and the number of runes consumed.
+func legacyHexColor(runes []rune, prefix rune) (TextColor, int) {
+	if len(runes) >= 8 && runes[1] == '#' {
+		if c, err := ParseColor(string(runes[1:8])); err == nil {
+			return c, 8
+		}
+	}
+
+	if len(runes) >= 14 && toLowerRune(runes[1]) == 'x' {
+		hex := make([]rune, 0, 7)
+		hex = append(hex, '#')
+		for j := 2; j < 14; j += 2 {
+			if runes[j] != prefix {
+				return "", 0
+			}
+			hex = append(hex, runes[j+1])
+		}
+		if c, err := ParseColor(string(hex)); err == nil {
+			return c, 14
+		}
+	}
+
+	return "", 0
+}
+
+func toLowerRune(r rune) rune {
+	if r >= 'A' && r <= 'Z' {
+		return r + ('a' - 'A')
+	}
+	return r
+}
+
+// ============
+
+// RenderLegacy - converts a text component into text with legacy formatting codes.
+// Hex colors are written in the "<p>x<p>R<p>R<p>G<p>G<p>B<p>B" form.
+// Click and hover events and fonts cannot be expressed and are dropped.
+func RenderLegacy(c TextComponent, prefix rune) string {
+	var (
+		b       strings.Builder
+		current textStyle
+	)
+
+	for _, span := range flattenText(c, nil) {
+		style := span.style
+		style.Font, style.Click, style.Hover = "", nil, nil
+
+		if style != current {
+			writeLegacyStyle(&b, current, style, prefix)
+			current = style
+		}
+
+		b.WriteString(span.text)
+	}
+
+	return b.String()
+}
+
+func writeLegacyStyle(b *strings.Builder, from, to textStyle, prefix rune) {
+	code := func(c rune) {
+		b.WriteRune(prefix)
+		b.WriteRune(c)
+	}
+
+	// turning a flag off or dropping the color needs a reset
+	needReset := (from.Color != "" && to.Color == "") ||
+		(from.Bold && !to.Bold) || (from.Italic && !to.Italic) ||
+		(from.Underlined && !to.Underlined) || (from.Strikethrough && !to.Strikethrough) ||
+		(from.Obfuscated && !to.Obfuscated)
+
+	switch {
+	case to.Color != "" && (to.Color != from.Color || needReset):
+		writeLegacyColor(b, to.Color, prefix)
+		from = textStyle{Color: to.Color}
+	case needReset:
+		code('r')
+		from = textStyle{}
+	}
+
+	if to.Obfuscated && !from.Obfuscated {
+		code('k')
+	}
+	if to.Bold && !from.Bold {
+		code('l')
+	}
+	if to.Strikethrough && !from.Strikethrough {
+		code('m')
+	}
+	if to.Underlined && !from.Underlined {
+		code('n')
+	}
+	if to.Italic && !from.Italic {
+		code('o')
+	}
+}
+
+func writeLegacyColor(b *strings.Builder, color TextColor, prefix rune) {
+	if !color.IsHex() {
+		for i, named := range NamedColors() {
+			if named == color {
+				b.WriteRune(prefix)
+				b.WriteByte(legacyColorCodes[i])
+				return
+			}
+		}
+		return
+	}
+
+	b.WriteRune(prefix)
+	b.WriteRune('x')
+	for _, r := range strings.ToLower(string(color[1:])) {
+		b.WriteRune(prefix)
+		b.WriteRune(r)
+	}
+}
@@ -0,0 +1,336 @@
+package gomcsmp
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseMarkup - converts MiniMessage-style tag markup into a text component.
+//
+// Supported tags:
+//
+//	<gold>, <color:gold>, <#RRGGBB>, <color:#RRGGBB>  text color
+//	<bold> <b>, <italic> <i> <em>, <underlined> <u>,
+//	<strikethrough> <st>, <obfuscated> <obf>            formatting
+//	<font:namespace:name>                               font
+//	<click:open_url:'https://...'>                      click event of any action
+//	<hover:show_text:'<red>markup'>                     hover text
+//	<reset>, <newline> <br>
+//
+// A closing tag </name> ends the most recent tag of the same kind
+// together with everything opened after it. "\<" writes a literal "<".
+// Unknown or malformed tags are kept as text.
+func ParseMarkup(s string) TextComponent {
+	return spansComponent(parseMarkupSpans(s))
+}
+
+type markupFrame struct {
+	kind  string
+	style textStyle
+}
+
+func parseMarkupSpans(s string) []textSpan {
+	var (
+		spans []textSpan
+		stack []markupFrame
+		text  strings.Builder
+	)
+
+	current := func() textStyle {
+		if len(stack) == 0 {
+			return textStyle{}
+		}
+		return stack[len(stack)-1].style
+	}
+
+	flush := func() {
+		spans = appendSpan(spans, textSpan{text: text.String(), style: current()})
+		text.Reset()
+	}
+
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+
+		if ch == '\\' && i+1 < len(s) && (s[i+1] == '<' || s[i+1] == '\\') {
+			text.WriteByte(s[i+1])
+			i++
+			continue
+		}
+
+		if ch != '<' {
+			text.WriteByte(ch)
+			continue
+		}
+
+		end := markupTagEnd(s, i+1)
+		if end < 0 {
+			text.WriteByte(ch)
+			continue
+		}
+
+		tag := s[i+1 : end]
+
+		if name, ok := strings.CutPrefix(tag, "/"); ok {
+			kind := markupKind(strings.ToLower(name))
+			idx := -1
+			for j := len(stack) - 1; j >= 0; j-- {
+				if stack[j].kind == kind {
+					idx = j
+					break
+				}
+			}
+			if idx < 0 {
+				text.WriteString(s[i : end+1])
+			} else {
+				flush()
+				stack = stack[:idx]
+			}
+			i = end
+			continue
+		}
+
+		kind, style, ok := applyMarkupTag(tag, current())
+		if !ok {
+			text.WriteString(s[i : end+1])
+			i = end
+			continue
+		}
+
+		switch kind {
+		case "newline":
+			text.WriteByte('\n')
+		case "reset":
+			flush()
+			stack = stack[:0]
+		default:
+			flush()
+			stack = append(stack, markupFrame{kind: kind, style: style})
+		}
+		i = end
+	}
+
+	flush()
+	return spans
+}
+
+// markupTagEnd - returns the index of the '>' closing a tag starting at from,
+// skipping quoted arguments, or -1.
+func markupTagEnd(s string, from int) int {
+	var quote byte
+	for i := from; i < len(s); i++ {
+		switch ch := s[i]; {
+		case quote != 0 && ch == '\\':
+			i++
+		case quote != 0 && ch == quote:
+			quote = 0
+		case quote != 0:
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == '<':
+			return -1
+		case ch == '>':
+			return i
+		}
+	}
+	return -1
+}
+
+// markupKind - maps tag names and aliases to the kind closed by </name>.
+func markupKind(name string) string {
+	name, _, _ = strings.Cut(name, ":")
+
+	switch name {
+	case "b", "bold":
+		return "bold"
+	case "i", "em", "italic":
+		return "italic"
+	case "u", "underlined":
+		return "underlined"
+	case "st", "strikethrough":
+		return "strikethrough"
+	case "obf", "obfuscated":
+		return "obfuscated"
+	case "color", "colour", "c":
+		return "color"
+	case "br", "newline":
+		return "newline"
+	case "font", "click", "hover", "reset":
+		return name
+	}
+
+	if _, err := ParseColor(name); err == nil {
+		return "color"
+	}
+	return ""
+}
+
+// applyMarkupTag - applies an opening tag to the style.
+func applyMarkupTag(tag string, style textStyle) (kind string, out textStyle, ok bool) {
+	name, arg, _ := strings.Cut(tag, ":")
+	kind = markupKind(strings.ToLower(name))
+
+	switch kind {
+	case "bold":
+		style.Bold = true
+	case "italic":
+		style.Italic = true
+	case "underlined":
+		style.Underlined = true
+	case "strikethrough":
+		style.Strikethrough = true
+	case "obfuscated":
+		style.Obfuscated = true
+	case "newline", "reset":
+	case "font":
+		if arg == "" {
+			return "", style, false
+		}
+		style.Font = unquoteMarkupArg(arg)
+	case "color":
+		value := name
+		if arg != "" {
+			value = unquoteMarkupArg(arg)
+		}
+		color, err := ParseColor(value)
+		if err != nil {
+			return "", style, false
+		}
+		style.Color = color
+	case "click":
+		action, value, found := strings.Cut(arg, ":")
+		if !found {
+			return "", style, false
+		}
+		style.Click = markupClickEvent(ClickAction(strings.ToLower(action)), unquoteMarkupArg(value))
+		if style.Click == nil {
+			return "", style, false
+		}
+	case "hover":
+		action, value, found := strings.Cut(arg, ":")
+		if !found || HoverAction(strings.ToLower(action)) != HoverShowText {
+			return "", style, false
+		}
+		style.Hover = ShowText(ParseMarkup(unquoteMarkupArg(value)))
+	default:
+		return "", style, false
+	}
+
+	return kind, style, true
+}
+
+func markupClickEvent(action ClickAction, value string) *ClickEvent {
+	switch action {
+	case ClickOpenURL:
+		return OpenURL(value)
+	case ClickRunCommand:
+		return RunCommand(value)
+	case ClickSuggestCommand:
+		return SuggestCommand(value)
+	case ClickCopyToClipboard:
+		return CopyToClipboard(value)
+	case ClickChangePage:
+		page, err := strconv.Atoi(value)
+		if err != nil {
+			return nil
+		}
+		return &ClickEvent{Action: ClickChangePage, Page: page}
+	}
+	return nil
+}
+
+func unquoteMarkupArg(s string) string {
+	if len(s) < 2 || (s[0] != '\'' && s[0] != '"') || s[len(s)-1] != s[0] {
+		return s
+	}
+
+	q := s[0]
+	s = s[1 : len(s)-1]
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && (s[i+1] == q || s[i+1] == '\\') {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func quoteMarkupArg(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	return "'" + r.Replace(s) + "'"
+}
+
+// ============
+
+// RenderMarkup - converts a text component into tag markup accepted by ParseMarkup.
+func RenderMarkup(c TextComponent) string {
+	var b strings.Builder
+
+	escape := strings.NewReplacer(`\`, `\\`, `<`, `\<`)
+
+	for _, span := range flattenText(c, nil) {
+		tags := markupTags(span.style)
+
+		for _, t := range tags {
+			b.WriteString("<" + t.open + ">")
+		}
+		b.WriteString(escape.Replace(span.text))
+		for i := len(tags) - 1; i >= 0; i-- {
+			b.WriteString("</" + tags[i].close + ">")
+		}
+	}
+
+	return b.String()
+}
+
+type markupTag struct {
+	open  string
+	close string
+}
+
+func markupTags(s textStyle) []markupTag {
+	var tags []markupTag
+
+	if s.Color != "" {
+		tags = append(tags, markupTag{"color:" + string(s.Color), "color"})
+	}
+	if s.Font != "" {
+		tags = append(tags, markupTag{"font:" + s.Font, "font"})
+	}
+
+	flags := []struct {
+		on   bool
+		name string
+	}{
+		{s.Bold, "bold"},
+		{s.Italic, "italic"},
+		{s.Underlined, "underlined"},
+		{s.Strikethrough, "strikethrough"},
+		{s.Obfuscated, "obfuscated"},
+	}
+	for _, f := range flags {
+		if f.on {
+			tags = append(tags, markupTag{f.name, f.name})
+		}
+	}
+
+	if s.Click != nil {
+		value := s.Click.Value
+		switch s.Click.Action {
+		case ClickOpenURL:
+			value = s.Click.URL
+		case ClickRunCommand, ClickSuggestCommand:
+			value = s.Click.Command
+		case ClickChangePage:
+			value = strconv.Itoa(s.Click.Page)
+		}
+		tags = append(tags, markupTag{"click:" + string(s.Click.Action) + ":" + quoteMarkupArg(value), "click"})
+	}
+
+	if s.Hover != nil && s.Hover.Action == HoverShowText && s.Hover.Value != nil {
+		tags = append(tags, markupTag{"hover:show_text:" + quoteMarkupArg(RenderMarkup(*s.Hover.Value)), "hover"})
+	}
+
+	return tags
+}
@@ -0,0 +1,249 @@
+package gomcsmp
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strings"
+)
+
+// LanguageTable - resolves translation keys into format strings,
+// e.g. "multiplayer.disconnect.kicked" into "Kicked by an operator".
+type LanguageTable interface {
+	Translate(key string) (format string, ok bool)
+}
+
+// LanguageMap - a LanguageTable backed by a map, the shape of vanilla lang files.
+type LanguageMap map[string]string
+
+func (m LanguageMap) Translate(key string) (string, bool) {
+	format, ok := m[key]
+	return format, ok
+}
+
+// ReadLanguage - reads a vanilla JSON lang file such as en_us.json.
+func ReadLanguage(rd io.Reader) (LanguageMap, error) {
+	m := LanguageMap{}
+	if err := json.NewDecoder(rd).Decode(&m); err != nil {
+		return nil, fmt.Errorf("read language: %w", err)
+	}
+	return m, nil
+}
+
+// LoadLanguage - reads a vanilla JSON lang file from disk.
+// The file is found in the client jar under assets/minecraft/lang/.
+func LoadLanguage(path string) (LanguageMap, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open language: %w", err)
+	}
+	defer f.Close()
+
+	return ReadLanguage(f)
+}
+
+// ============
+
+// ToComponent - converts the message into a text component.
+// A translatable message becomes a translatable component
+// with its params as arguments and the literal as fallback.
+// § formatting codes, as written by NewComponentMessage, are parsed.
+func (m Message) ToComponent() TextComponent {
+	switch {
+	case m.Translatable != "":
+		c := TextComponent{Translate: m.Translatable, Fallback: m.Literal}
+		for _, p := range m.TranslatableParams {
+			c.With = append(c.With, ParseLegacy(p, LegacySection))
+		}
+		return c
+	default:
+		return ParseLegacy(m.Literal, LegacySection)
+	}
+}
+
+// String - returns the plain text of the message without translating keys.
+func (m Message) String() string {
+	return NewTextRenderer(nil).Plain(m.ToComponent())
+}
+
+// ============
+
+// TextRenderer - renders text components as plain text, ANSI terminal output or HTML.
+// Translatable components are resolved through the language table;
+// keys missing from it render as their fallback or as the key followed by the arguments.
+type TextRenderer struct {
+	lang LanguageTable
+}
+
+// NewTextRenderer - creates a renderer resolving keys through lang, which may be nil.
+func NewTextRenderer(lang LanguageTable) *TextRenderer {
+	return &TextRenderer{lang: lang}
+}
+
+// Plain - renders the component as text without any styling.
+func (r *TextRenderer) Plain(c TextComponent) string {
+	var b strings.Builder
+	for _, span := range flattenText(c, r.lang) {
+		b.WriteString(span.text)
+	}
+	return b.String()
+}
+
+// PlainMessage - renders the message as text without any styling.
+func (r *TextRenderer) PlainMessage(m Message) string {
+	return r.Plain(m.ToComponent())
+}
+
+// ============
+
+const ansiReset = "\x1b[0m"
+
+// ansiNamedColors - SGR codes of the named colors, in NamedColors order.
+var ansiNamedColors = [16]int{
+	30, 34, 32, 36,
+	31, 35, 33, 37,
+	90, 94, 92, 96,
+	91, 95, 93, 97,
+}
+
+// ANSI - renders the component with ANSI escape sequences for terminals.
+// Named colors use the 16 standard terminal colors, hex colors use 24-bit color.
+// Obfuscated text, fonts and events are not rendered.
+func (r *TextRenderer) ANSI(c TextComponent) string {
+	var b strings.Builder
+
+	for _, span := range flattenText(c, r.lang) {
+		codes := ansiCodes(span.style)
+		if len(codes) == 0 {
+			b.WriteString(span.text)
+			continue
+		}
+
+		b.WriteString("\x1b[" + strings.Join(codes, ";") + "m")
+		b.WriteString(span.text)
+		b.WriteString(ansiReset)
+	}
+
+	return b.String()
+}
+
+// ANSIMessage - renders the message with ANSI escape sequences for terminals.
+func (r *TextRenderer) ANSIMessage(m Message) string {
+	return r.ANSI(m.ToComponent())
+}
+
+func ansiCodes(s textStyle) []string {
+	var codes []string
+
+	if s.Color != "" {
+		if s.Color.IsHex() {
+			if rgb, ok := s.Color.RGB(); ok {
+				codes = append(codes, fmt.Sprintf("38;2;%d;%d;%d", rgb>>16&0xFF, rgb>>8&0xFF, rgb&0xFF))
+			}
+		} else {
+			for i, named := range NamedColors() {
+				if named == s.Color {
+					codes = append(codes, fmt.Sprint(ansiNamedColors[i]))
+					break
+				}
+			}
+		}
+	}
+
+	flags := []struct {
+		on   bool
+		code string
+	}{
+		{s.Bold, "1"},
+		{s.Italic, "3"},
+		{s.Underlined, "4"},
+		{s.Strikethrough, "9"},
+	}
+	for _, f := range flags {
+		if f.on {
+			codes = append(codes, f.code)
+		}
+	}
+
+	return codes
+}
+
+// ============
+
+// HTML - renders the component as an HTML fragment.
+// Styled text is wrapped in <span> elements with inline styles,
+// open_url click events with http(s) urls become links and show_text hover events become titles.
+// Obfuscated text is marked with the "mc-obfuscated" class. Newlines become <br>.
+func (r *TextRenderer) HTML(c TextComponent) string {
+	var b strings.Builder
+
+	for _, span := range flattenText(c, r.lang) {
+		text := strings.ReplaceAll(html.EscapeString(span.text), "\n", "<br>")
+
+		// only http(s) urls become links, javascript: or data: ones would be stored XSS
+		link := span.style.Click != nil && span.style.Click.Action == ClickOpenURL &&
+			span.style.Click.validate() == nil
+
+		if link {
+			b.WriteString(`<a href="` + html.EscapeString(span.style.Click.URL) + `">`)
+		}
+
+		attrs := r.htmlAttrs(span.style)
+		if attrs != "" {
+			b.WriteString("<span" + attrs + ">" + text + "</span>")
+		} else {
+			b.WriteString(text)
+		}
+
+		if link {
+			b.WriteString("</a>")
+		}
+	}
+
+	return b.String()
+}
+
+// HTMLMessage - renders the message as an HTML fragment.
+func (r *TextRenderer) HTMLMessage(m Message) string {
+	return r.HTML(m.ToComponent())
+}
+
+func (r *TextRenderer) htmlAttrs(s textStyle) string {
+	var css []string
+
+	if rgb, ok := s.Color.RGB(); ok {
+		css = append(css, fmt.Sprintf("color:#%06X", rgb))
+	}
+	if s.Bold {
+		css = append(css, "font-weight:bold")
+	}
+	if s.Italic {
+		css = append(css, "font-style:italic")
+	}
+
+	var decoration []string
+	if s.Underlined {
+		decoration = append(decoration, "underline")
+	}
+	if s.Strikethrough {
+		decoration = append(decoration, "line-through")
+	}
+	if len(decoration) > 0 {
+		css = append(css, "text-decoration:"+strings.Join(decoration, " "))
+	}
+
+	var attrs strings.Builder
+	if len(css) > 0 {
+		attrs.WriteString(` style="` + strings.Join(css, ";") + `"`)
+	}
+	if s.Obfuscated {
+		attrs.WriteString(` class="mc-obfuscated"`)
+	}
+	if s.Hover != nil && s.Hover.Action == HoverShowText && s.Hover.Value != nil {
+		attrs.WriteString(` title="` + html.EscapeString(r.Plain(*s.Hover.Value)) + `"`)
+	}
+
+	return attrs.String()
+}
@@ -0,0 +1,110 @@
+package gomcsmp_test
+
+import (
+	"testing"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+)
+
+func TestHTMLEscaping(t *testing.T) {
+	tests := []struct {
+		name string
+		c    gomcsmp.TextComponent
+		want string
+	}{
+		{"text", gomcsmp.NewText(`<script>alert('x')</script> & "q"`).Build(),
+			`&lt;script&gt;alert(&#39;x&#39;)&lt;/script&gt; &amp; &#34;q&#34;`},
+		{"newline", gomcsmp.NewText("a<b\nc").Build(), "a&lt;b<br>c"},
+		{"hover title", gomcsmp.NewText("tip").Hover(gomcsmp.ShowText(gomcsmp.NewText(`"><img src=x>`).Build())).Build(),
+			`<span title="&#34;&gt;&lt;img src=x&gt;">tip</span>`},
+		{"link url", gomcsmp.NewText("a").Click(gomcsmp.OpenURL(`https://example.com/?a="b"&c=<d>`)).Build(),
+			`<a href="https://example.com/?a=&#34;b&#34;&amp;c=&lt;d&gt;">a</a>`},
+		{"styled", gomcsmp.NewText("<b>").Color(gomcsmp.ColorRed).Bold(true).Obfuscated(true).Build(),
+			`<span style="color:#FF5555;font-weight:bold" class="mc-obfuscated">&lt;b&gt;</span>`},
+	}
+
+	r := gomcsmp.NewTextRenderer(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.HTML(tt.c); got != tt.want {
+				t.Fatalf("HTML()\n got %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLLinks(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://example.com", `<a href="https://example.com">a</a>`},
+		{"http://example.com/x", `<a href="http://example.com/x">a</a>`},
+		{"HTTPS://example.com", `<a href="HTTPS://example.com">a</a>`},
+		{"javascript:alert(1)", "a"},
+		{"JavaScript:alert(1)", "a"},
+		{"data:text/html,<script>alert(1)</script>", "a"},
+		{"//example.com", "a"},
+		{"example.com", "a"},
+	}
+
+	r := gomcsmp.NewTextRenderer(nil)
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			c := gomcsmp.NewText("a").Click(gomcsmp.OpenURL(tt.url)).Build()
+			if got := r.HTML(c); got != tt.want {
+				t.Fatalf("HTML() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// other click actions never become links
+	c := gomcsmp.NewText("a").Click(gomcsmp.RunCommand("/say https://example.com")).Build()
+	if got := r.HTML(c); got != "a" {
+		t.Fatalf("HTML() of a run_command = %s, want plain text", got)
+	}
+}
+
+func TestTextRendererTranslate(t *testing.T) {
+	lang := gomcsmp.LanguageMap{
+		"chat.type.text": "<%s> %s",
+		"swapped":        "%2$s then %1$s",
+	}
+	steve, hi := gomcsmp.NewText("Steve").Build(), gomcsmp.NewText("hi").Build()
+
+	tests := []struct {
+		name string
+		c    gomcsmp.TextComponent
+		want string
+	}{
+		{"args", gomcsmp.NewTranslatable("chat.type.text", steve, hi).Build(), "<Steve> hi"},
+		{"positional args", gomcsmp.NewTranslatable("swapped", steve, hi).Build(), "hi then Steve"},
+		{"missing key", gomcsmp.NewTranslatable("missing", steve).Build(), "missing Steve"},
+		{"missing key fallback", gomcsmp.NewTranslatable("missing", steve).Fallback("fallback").Build(), "fallback"},
+	}
+
+	r := gomcsmp.NewTextRenderer(lang)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Plain(tt.c); got != tt.want {
+				t.Fatalf("Plain() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got, want := r.HTML(gomcsmp.NewTranslatable("chat.type.text", steve, hi).Build()), "&lt;Steve&gt; hi"; got != want {
+		t.Fatalf("HTML() = %q, want %q", got, want)
+	}
+}
+
+func TestANSI(t *testing.T) {
+	r := gomcsmp.NewTextRenderer(nil)
+
+	c := gomcsmp.NewText("a").Color(gomcsmp.ColorRed).Bold(true).Build()
+	if got, want := r.ANSI(c), "\x1b[91;1ma\x1b[0m"; got != want {
+		t.Fatalf("ANSI() = %q, want %q", got, want)
+	}
+	if got := r.ANSI(gomcsmp.NewText("plain").Build()); got != "plain" {
+		t.Fatalf("ANSI() of plain text = %q, want it unchanged", got)
+	}
+}
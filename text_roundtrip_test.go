@@ -0,0 +1,170 @@
+package gomcsmp
+
+import (
+	"reflect"
+	"testing"
+)
+
+// roundTripComponents - components whose styles can be written in both formats,
+// except fonts and events which legacy codes drop.
+var roundTripComponents = []struct {
+	name string
+	c    TextComponent
+}{
+	{"plain", NewText("hello world").Build()},
+	{"empty", TextComponent{}},
+	{"named color", NewText("gold text").Color(ColorGold).Build()},
+	{"every named color", func() TextComponent {
+		b := NewText("")
+		for _, color := range NamedColors() {
+			b.Append(NewText(string(color)).Color(color).Build())
+		}
+		return b.Build()
+	}()},
+	{"hex color", NewText("hex").Color(HexColor(0x12, 0xAB, 0xEF)).Build()},
+	{"formats", NewText("all").Bold(true).Italic(true).Underlined(true).Strikethrough(true).Obfuscated(true).Build()},
+	{"nested formats", NewText("a").Color(ColorRed).Append(
+		NewText("b").Bold(true).Append(
+			NewText("c").Italic(true).Build(),
+		).Build(),
+		NewText("d").Build(),
+	).Build()},
+	{"format turned off", NewText("x").Bold(true).Color(ColorAqua).Append(
+		NewText("y").Bold(false).Build(),
+		NewText("z").Color(ColorGreen).Build(),
+	).Build()},
+	{"color then plain", NewText("").Append(
+		NewText("red").Color(ColorRed).Build(),
+		NewText(" plain").Build(),
+	).Build()},
+	{"click", NewText("site").Color(ColorBlue).Click(OpenURL("https://example.com/a?b='c'")).Build()},
+	{"click command", NewText("run").Click(RunCommand("/say <hi>")).Build()},
+	{"hover", NewText("tip").Hover(ShowText(NewText("hover <text>").Color(ColorYellow).Build())).Build()},
+	{"font", NewText("alt").Font("minecraft:alt").Build()},
+	{"angle brackets", NewText(`a < b \ c`).Build()},
+}
+
+// legacySpans - returns the spans of c without the styles legacy codes cannot express.
+func legacySpans(c TextComponent) []textSpan {
+	var spans []textSpan
+	for _, s := range flattenText(c, nil) {
+		s.style.Font, s.style.Click, s.style.Hover = "", nil, nil
+		spans = appendSpan(spans, s)
+	}
+	return spans
+}
+
+func TestLegacyRoundTrip(t *testing.T) {
+	for _, prefix := range []rune{LegacySection, LegacyAmpersand} {
+		for _, tt := range roundTripComponents {
+			t.Run(string(prefix)+" "+tt.name, func(t *testing.T) {
+				rendered := RenderLegacy(tt.c, prefix)
+				got := legacySpans(ParseLegacy(rendered, prefix))
+				want := legacySpans(tt.c)
+
+				if !reflect.DeepEqual(got, want) {
+					t.Fatalf("ParseLegacy(%q)\n got %+v\nwant %+v", rendered, got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestLegacyRoundTripText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"colors", "&cred &agreen &rplain"},
+		{"hex", "&x&1&2&a&b&e&fhex &#FF00AAshort"},
+		{"formats", "&6&l&ngold bold underlined&r done"},
+		{"unknown code", "&zkept &"},
+		{"upper case codes", "&CRED&LBOLD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := ParseLegacy(tt.in, LegacyAmpersand)
+			rendered := RenderLegacy(first, LegacyAmpersand)
+			second := ParseLegacy(rendered, LegacyAmpersand)
+
+			if got, want := flattenText(second, nil), flattenText(first, nil); !reflect.DeepEqual(got, want) {
+				t.Fatalf("ParseLegacy(%q) via %q\n got %+v\nwant %+v", tt.in, rendered, got, want)
+			}
+		})
+	}
+}
+
+func TestMarkupRoundTrip(t *testing.T) {
+	for _, tt := range roundTripComponents {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered := RenderMarkup(tt.c)
+			got := flattenText(ParseMarkup(rendered), nil)
+			want := flattenText(tt.c, nil)
+
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("ParseMarkup(%q)\n got %+v\nwant %+v", rendered, got, want)
+			}
+		})
+	}
+}
+
+func TestMarkupRoundTripText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		text string
+	}{
+		{"colors", "<red>red</red><#00FF00>green</#00ff00><color:gold>gold</color>", "redgreengold"},
+		{"nested", "<blue>a<b>b<i>c</i></b>d</blue>", "abcd"},
+		{"close outer", "<u>a<st>b</u>c", "abc"},
+		{"click", "<click:open_url:'https://example.com'>link</click>", "link"},
+		{"hover", "<hover:show_text:'<red>tip'>text</hover>", "text"},
+		{"escapes", `\<red> and \\ stay`, `<red> and \ stay`},
+		{"unknown tags", "<wave>a</wave> <color:nope>b</color>", "<wave>a</wave> <color:nope>b</color>"},
+		{"unclosed", "a < b", "a < b"},
+		{"reset", "<red><b>x<reset>y", "xy"},
+		{"newline", "a<br>b", "a\nb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := ParseMarkup(tt.in)
+			if got := first.PlainText(); got != tt.text {
+				t.Fatalf("ParseMarkup(%q) text = %q, want %q", tt.in, got, tt.text)
+			}
+
+			rendered := RenderMarkup(first)
+			second := ParseMarkup(rendered)
+
+			if got, want := flattenText(second, nil), flattenText(first, nil); !reflect.DeepEqual(got, want) {
+				t.Fatalf("ParseMarkup(%q) via %q\n got %+v\nwant %+v", tt.in, rendered, got, want)
+			}
+		})
+	}
+}
+
+func TestComponentMessage(t *testing.T) {
+	for _, tt := range roundTripComponents {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewComponentMessage(tt.c)
+			got := legacySpans(ParseLegacy(m.Literal, LegacySection))
+			want := legacySpans(tt.c)
+
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("literal %q\n got %+v\nwant %+v", m.Literal, got, want)
+			}
+			if got, want := m.String(), tt.c.PlainText(); got != want {
+				t.Fatalf("String() = %q, want %q", got, want)
+			}
+		})
+	}
+
+	m := NewTranslatable("chat.type.text", NewText("Steve").Color(ColorGold).Build()).Message()
+	if m.Translatable != "chat.type.text" {
+		t.Fatalf("Translatable = %q, want chat.type.text", m.Translatable)
+	}
+	if want := []string{"§6Steve"}; !reflect.DeepEqual(m.TranslatableParams, want) {
+		t.Fatalf("TranslatableParams = %q, want %q", m.TranslatableParams, want)
+	}
+}
@@ -0,0 +1,212 @@
+package gomcsmp
+
+import (
+	"strconv"
+	"strings"
+)
+
+// textStyle - the fully resolved style of a piece of text.
+type textStyle struct {
+	Color         TextColor
+	Font          string
+	Bold          bool
+	Italic        bool
+	Underlined    bool
+	Strikethrough bool
+	Obfuscated    bool
+	Click         *ClickEvent
+	Hover         *HoverEvent
+}
+
+// inherit - returns the style of a child component c under s.
+func (s textStyle) inherit(c *TextComponent) textStyle {
+	if c.Color != "" {
+		s.Color = c.Color
+	}
+	if c.Font != "" {
+		s.Font = c.Font
+	}
+	if c.Bold != nil {
+		s.Bold = *c.Bold
+	}
+	if c.Italic != nil {
+		s.Italic = *c.Italic
+	}
+	if c.Underlined != nil {
+		s.Underlined = *c.Underlined
+	}
+	if c.Strikethrough != nil {
+		s.Strikethrough = *c.Strikethrough
+	}
+	if c.Obfuscated != nil {
+		s.Obfuscated = *c.Obfuscated
+	}
+	if c.ClickEvent != nil {
+		s.Click = c.ClickEvent
+	}
+	if c.HoverEvent != nil {
+		s.Hover = c.HoverEvent
+	}
+	return s
+}
+
+// decorated - reports whether any formatting flag is set.
+func (s textStyle) decorated() bool {
+	return s.Bold || s.Italic || s.Underlined || s.Strikethrough || s.Obfuscated
+}
+
+// component - returns a literal component carrying the style explicitly.
+func (s textStyle) component(text string) TextComponent {
+	c := TextComponent{
+		Text:       text,
+		Color:      s.Color,
+		Font:       s.Font,
+		ClickEvent: s.Click,
+		HoverEvent: s.Hover,
+	}
+
+	flags := []struct {
+		on  bool
+		dst **bool
+	}{
+		{s.Bold, &c.Bold},
+		{s.Italic, &c.Italic},
+		{s.Underlined, &c.Underlined},
+		{s.Strikethrough, &c.Strikethrough},
+		{s.Obfuscated, &c.Obfuscated},
+	}
+	for _, f := range flags {
+		if f.on {
+			*f.dst = boolPtr(true)
+		}
+	}
+
+	return c
+}
+
+// ============
+
+// textSpan - a run of text sharing one resolved style.
+type textSpan struct {
+	text  string
+	style textStyle
+}
+
+// flattenText - resolves a component tree into styled spans in reading order,
+// translating keys through lang when it is not nil.
+// Adjacent spans with the same style are merged.
+func flattenText(c TextComponent, lang LanguageTable) []textSpan {
+	var spans []textSpan
+	walkText(&c, textStyle{}, lang, func(text string, style textStyle) {
+		spans = appendSpan(spans, textSpan{text: text, style: style})
+	})
+	return spans
+}
+
+func walkText(c *TextComponent, parent textStyle, lang LanguageTable, emit func(text string, style textStyle)) {
+	style := parent.inherit(c)
+
+	switch {
+	case c.Translate != "":
+		if lang != nil {
+			if format, ok := lang.Translate(c.Translate); ok {
+				walkTranslation(format, c.With, style, lang, emit)
+				break
+			}
+		}
+		if c.Fallback != "" {
+			emit(c.Fallback, style)
+			break
+		}
+		emit(c.Translate, style)
+		for i := range c.With {
+			emit(" ", style)
+			walkText(&c.With[i], style, lang, emit)
+		}
+	default:
+		emit(c.Text, style)
+	}
+
+	for i := range c.Extra {
+		walkText(&c.Extra[i], style, lang, emit)
+	}
+}
+
+// walkTranslation - expands a translation format the way vanilla does:
+// "%s" takes the next argument, "%2$s" takes the second one and "%%" is a percent sign.
+// Arguments out of range render as nothing, unknown specifiers are kept as text.
+func walkTranslation(format string, args []TextComponent, style textStyle, lang LanguageTable, emit func(text string, style textStyle)) {
+	next := 0
+
+	for i := 0; i < len(format); {
+		j := strings.IndexByte(format[i:], '%')
+		if j < 0 {
+			emit(format[i:], style)
+			return
+		}
+		emit(format[i:i+j], style)
+		i += j
+
+		k := i + 1
+		for k < len(format) && format[k] >= '0' && format[k] <= '9' {
+			k++
+		}
+
+		idx := -1
+		if k > i+1 && k < len(format) && format[k] == '$' {
+			idx, _ = strconv.Atoi(format[i+1 : k])
+			idx--
+			k++
+		} else {
+			k = i + 1
+		}
+
+		if k >= len(format) {
+			emit(format[i:], style)
+			return
+		}
+
+		switch format[k] {
+		case '%':
+			emit("%", style)
+		case 's', 'd':
+			if idx < 0 {
+				idx = next
+				next++
+			}
+			if idx >= 0 && idx < len(args) {
+				walkText(&args[idx], style, lang, emit)
+			}
+		default:
+			emit(format[i:k+1], style)
+		}
+		i = k + 1
+	}
+}
+
+func appendSpan(spans []textSpan, s textSpan) []textSpan {
+	if s.text == "" {
+		return spans
+	}
+	if n := len(spans); n > 0 && spans[n-1].style == s.style {
+		spans[n-1].text += s.text
+		return spans
+	}
+	return append(spans, s)
+}
+
+// spansComponent - builds a component from flat spans.
+func spansComponent(spans []textSpan) TextComponent {
+	switch len(spans) {
+	case 0:
+		return TextComponent{}
+	case 1:
+		return spans[0].style.component(spans[0].text)
+	}
+
+	root := TextComponent{Extra: make([]TextComponent, 0, len(spans))}
+	for _, s := range spans {
+		root.Extra = append(root.Extra, s.style.component(s.text))
+	}
+	return root
+}
@@ -0,0 +1,173 @@
+package gomcsmp
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Attribute - a key-value pair attached to a span.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Attr - creates a new span Attribute.
+func Attr(key string, value any) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span - a single traced operation opened by a Tracer.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Tracer - opens spans around client calls.
+// Start must return a context carrying the new span,
+// so that nested calls made with that context become its children.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// ============
+
+type spanContextKey struct{}
+
+// ContextWithSpan - returns a copy of ctx carrying the given span.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+// SpanFromContext - returns the span carried in ctx or nil.
+func SpanFromContext(ctx context.Context) Span {
+	span, _ := ctx.Value(spanContextKey{}).(Span)
+	return span
+}
+
+// ============
+
+// NoopTracer - a Tracer which records nothing. Used by default.
+type NoopTracer struct{}
+
+func (NoopTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(attrs ...Attribute) {}
+func (noopSpan) RecordError(err error)            {}
+func (noopSpan) End()                             {}
+
+// ============
+
+// RecordingTracer - an in-memory Tracer which keeps every span it opens.
+// Intended for tests and debugging.
+type RecordingTracer struct {
+	mu     sync.Mutex
+	nextID uint64
+	spans  []*RecordedSpan
+}
+
+// NewRecordingTracer - creates an empty RecordingTracer.
+func NewRecordingTracer() *RecordingTracer {
+	return &RecordingTracer{}
+}
+
+// RecordedSpan - a span stored by RecordingTracer.
+// ParentID is zero for root spans.
+type RecordedSpan struct {
+	ID         uint64
+	ParentID   uint64
+	Name       string
+	Attributes []Attribute
+	Err        error
+	StartTime  time.Time
+	EndTime    time.Time
+
+	tracer *RecordingTracer
+}
+
+func (t *RecordingTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.nextID++
+	span := &RecordedSpan{
+		ID:         t.nextID,
+		Name:       name,
+		Attributes: append([]Attribute(nil), attrs...),
+		StartTime:  time.Now(),
+		tracer:     t,
+	}
+
+	if parent, ok := SpanFromContext(ctx).(*RecordedSpan); ok && parent.tracer == t {
+		span.ParentID = parent.ID
+	}
+
+	t.spans = append(t.spans, span)
+	return ContextWithSpan(ctx, span), span
+}
+
+// Spans - returns a snapshot of all recorded spans in start order.
+func (t *RecordingTracer) Spans() []RecordedSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out := make([]RecordedSpan, 0, len(t.spans))
+	for _, s := range t.spans {
+		c := *s
+		c.Attributes = append([]Attribute(nil), s.Attributes...)
+		out = append(out, c)
+	}
+	return out
+}
+
+// Children - returns a snapshot of spans whose parent is the given span ID.
+func (t *RecordingTracer) Children(id uint64) []RecordedSpan {
+	out := []RecordedSpan{}
+	for _, s := range t.Spans() {
+		if s.ParentID == id {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Reset - drops all recorded spans.
+func (t *RecordingTracer) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = nil
+}
+
+func (s *RecordedSpan) SetAttributes(attrs ...Attribute) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.Attributes = append(s.Attributes, attrs...)
+}
+
+func (s *RecordedSpan) RecordError(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.Err = err
+}
+
+func (s *RecordedSpan) End() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	if s.EndTime.IsZero() {
+		s.EndTime = time.Now()
+	}
+}
+
+// Ended - reports whether End was called on the span.
+func (s *RecordedSpan) Ended() bool {
+	if s.tracer != nil {
+		s.tracer.mu.Lock()
+		defer s.tracer.mu.Unlock()
+	}
+	return !s.EndTime.IsZero()
+}
@@ -0,0 +1,116 @@
+package gomcsmp_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	gomcsmp "github.com/eterline/go-mc-smp"
+	"github.com/eterline/go-mc-smp/mcsmptest"
+)
+
+func TestRecordingTracerNesting(t *testing.T) {
+	tracer := gomcsmp.NewRecordingTracer()
+
+	ctx, root := tracer.Start(context.Background(), "root", gomcsmp.Attr("a", 1))
+	_, child := tracer.Start(ctx, "child")
+	child.SetAttributes(gomcsmp.Attr("b", 2))
+	child.RecordError(errors.New("boom"))
+	child.End()
+
+	spans := tracer.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(spans))
+	}
+	if spans[0].ParentID != 0 || spans[1].ParentID != spans[0].ID {
+		t.Fatalf("parents = %d, %d, want 0, %d", spans[0].ParentID, spans[1].ParentID, spans[0].ID)
+	}
+	if spans[0].Ended() {
+		t.Fatal("root span ended before End")
+	}
+	if !spans[1].Ended() || spans[1].Err == nil || len(spans[1].Attributes) != 1 {
+		t.Fatalf("child span = %+v, want ended with an error and one attribute", spans[1])
+	}
+
+	children := tracer.Children(spans[0].ID)
+	if len(children) != 1 || children[0].Name != "child" {
+		t.Fatalf("children = %+v, want the child span", children)
+	}
+
+	root.End()
+	end := tracer.Spans()[0].EndTime
+	root.End()
+	if got := tracer.Spans()[0].EndTime; !got.Equal(end) {
+		t.Fatal("second End moved the end time")
+	}
+
+	tracer.Reset()
+	if n := len(tracer.Spans()); n != 0 {
+		t.Fatalf("%d spans after Reset, want 0", n)
+	}
+}
+
+func TestRecordingTracerForeignParent(t *testing.T) {
+	other := gomcsmp.NewRecordingTracer()
+	ctx, _ := other.Start(context.Background(), "other")
+
+	tracer := gomcsmp.NewRecordingTracer()
+	tracer.Start(ctx, "span")
+
+	if parent := tracer.Spans()[0].ParentID; parent != 0 {
+		t.Fatalf("parent = %d, want a root span", parent)
+	}
+}
+
+func TestRecordingTracerConcurrent(t *testing.T) {
+	tracer := gomcsmp.NewRecordingTracer()
+	ctx, root := tracer.Start(context.Background(), "root")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		_, span := tracer.Start(ctx, "child")
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			span.SetAttributes(gomcsmp.Attr("i", i))
+			span.End()
+		}()
+		go func() {
+			defer wg.Done()
+			for !span.(*gomcsmp.RecordedSpan).Ended() {
+				tracer.Spans()
+			}
+		}()
+	}
+	wg.Wait()
+	root.End()
+
+	if n := len(tracer.Children(tracer.Spans()[0].ID)); n != 8 {
+		t.Fatalf("%d children, want 8", n)
+	}
+}
+
+func TestClientTracing(t *testing.T) {
+	tracer := gomcsmp.NewRecordingTracer()
+
+	srv := mcsmptest.Start(t, mcsmptest.WithFaultPlan(mcsmptest.NewFaultPlan(mcsmptest.FailWith("minecraft:bans", "boom"))))
+	client := srv.TestClient(t, gomcsmp.WithTracer(tracer))
+
+	ctx, parent := tracer.Start(context.Background(), "sync")
+	client.AllowlistGet(ctx)
+	client.BansGet(ctx)
+	parent.End()
+
+	spans := tracer.Children(tracer.Spans()[0].ID)
+	if len(spans) != 2 {
+		t.Fatalf("%d call spans, want 2", len(spans))
+	}
+	if spans[0].Name != "minecraft:allowlist" || spans[0].Err != nil || !spans[0].Ended() {
+		t.Fatalf("allowlist span = %+v, want an ended span without error", spans[0])
+	}
+	if spans[1].Name != "minecraft:bans" || spans[1].Err == nil {
+		t.Fatalf("bans span = %+v, want the server error recorded", spans[1])
+	}
+}